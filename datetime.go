@@ -0,0 +1,752 @@
+package discordgoi18n
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	// Date and time styles, as defined by CLDR.
+	styleShort  = "short"
+	styleMedium = "medium"
+	styleLong   = "long"
+
+	// patternQuote delimits literal text in CLDR date patterns, doubled to escape itself.
+	patternQuote = '\''
+	// unitPlaceholder is replaced by the formatted value in duration unit patterns.
+	unitPlaceholder = "{0}"
+
+	hoursPerDay    = 24
+	hoursPerPeriod = 12
+	twoDigits      = 2
+	fullMonthName  = 4
+	shortMonthName = 3
+	centuryModulo  = 100
+
+	secondsPerMinute = 60
+	secondsPerHour   = 3600
+)
+
+// durationUnit is a unit a duration is broken down into, from the largest to the smallest.
+type durationUnit int
+
+const (
+	unitDay durationUnit = iota
+	unitHour
+	unitMinute
+	unitSecond
+	unitCount
+)
+
+// unitPattern holds the CLDR long patterns of a duration unit by plural category; categories
+// which are not provided fall back on pluralOther.
+type unitPattern map[pluralCategory]string
+
+// dateTimeFormat holds the CLDR Gregorian names and patterns used to format dates and times
+// in a locale.
+type dateTimeFormat struct {
+	months      [12]string
+	shortMonths [12]string
+	periods     [2]string // AM and PM markers
+	dates       map[string]string
+	times       map[string]string
+}
+
+// newDateTimeFormat returns the CLDR Gregorian date and time patterns of locale; unknown
+// locales are formatted as en-US.
+//
+//nolint:exhaustive,funlen // Locales without any format fall back on the en-US one, long but readable.
+func newDateTimeFormat(locale discordgo.Locale) dateTimeFormat {
+	format := dateTimeFormat{periods: [2]string{"AM", "PM"}}
+	format.months, format.shortMonths = monthNames(locale)
+
+	var shortDate, mediumDate, longDate, longTime string
+	shortTime, mediumTime := "HH:mm", "HH:mm:ss"
+	switch locale {
+	case discordgo.EnglishGB:
+		shortDate, mediumDate, longDate = "dd/MM/y", "d MMM y", "d MMMM y"
+	case discordgo.Bulgarian:
+		shortDate, mediumDate, longDate = "d.MM.yy 'г'.", "d.MM.y 'г'.", "d MMMM y 'г'."
+		shortTime, mediumTime = "H:mm 'ч'.", "H:mm:ss 'ч'."
+	case discordgo.ChineseCN:
+		shortDate, mediumDate, longDate = "y/M/d", "y年M月d日", "y年M月d日"
+		longTime = "z HH:mm:ss"
+	case discordgo.ChineseTW:
+		shortDate, mediumDate, longDate = "y/M/d", "y年M月d日", "y年M月d日"
+		shortTime, mediumTime, longTime = "ah:mm", "ah:mm:ss", "z ah:mm:ss"
+		format.periods = [2]string{"上午", "下午"}
+	case discordgo.Croatian:
+		shortDate, mediumDate, longDate = "dd. MM. y.", "d. MMM y.", "d. MMMM y."
+		longTime = "HH:mm:ss (z)"
+	case discordgo.Czech:
+		shortDate, mediumDate, longDate = "dd.MM.yy", "d. M. y", "d. MMMM y"
+		shortTime, mediumTime = "H:mm", "H:mm:ss"
+	case discordgo.Danish:
+		shortDate, mediumDate, longDate = "dd.MM.y", "d. MMM y", "d. MMMM y"
+		shortTime, mediumTime = "HH.mm", "HH.mm.ss"
+	case discordgo.Dutch:
+		shortDate, mediumDate, longDate = "dd-MM-y", "d MMM y", "d MMMM y"
+	case discordgo.Finnish:
+		shortDate, mediumDate, longDate = "d.M.y", "d.M.y", "d. MMMM y"
+		shortTime, mediumTime = "H.mm", "H.mm.ss"
+	case discordgo.French:
+		shortDate, mediumDate, longDate = "dd/MM/y", "d MMM y", "d MMMM y"
+	case discordgo.German:
+		shortDate, mediumDate, longDate = "dd.MM.yy", "dd.MM.y", "d. MMMM y"
+	case discordgo.Greek:
+		shortDate, mediumDate, longDate = "d/M/yy", "d MMM y", "d MMMM y"
+		shortTime, mediumTime = "h:mm a", "h:mm:ss a"
+		format.periods = [2]string{"π.μ.", "μ.μ."}
+	case discordgo.Hindi:
+		shortDate, mediumDate, longDate = "d/M/yy", "d MMM y", "d MMMM y"
+		shortTime, mediumTime = "h:mm a", "h:mm:ss a"
+		format.periods = [2]string{"am", "pm"}
+	case discordgo.Hungarian:
+		shortDate, mediumDate, longDate = "y. MM. dd.", "y. MMM d.", "y. MMMM d."
+		shortTime, mediumTime = "H:mm", "H:mm:ss"
+	case discordgo.Italian:
+		shortDate, mediumDate, longDate = "dd/MM/yy", "d MMM y", "d MMMM y"
+	case discordgo.Japanese:
+		shortDate, mediumDate, longDate = "y/MM/dd", "y/MM/dd", "y年M月d日"
+		shortTime, mediumTime = "H:mm", "H:mm:ss"
+	case discordgo.Korean:
+		shortDate, mediumDate, longDate = "yy. M. d.", "y. M. d.", "y년 M월 d일"
+		shortTime, mediumTime, longTime = "a h:mm", "a h:mm:ss", "a h시 m분 s초 z"
+		format.periods = [2]string{"오전", "오후"}
+	case discordgo.Lithuanian:
+		shortDate, mediumDate, longDate = "y-MM-dd", "y-MM-dd", "y 'm'. MMMM d 'd'."
+	case discordgo.Norwegian:
+		shortDate, mediumDate, longDate = "dd.MM.y", "d. MMM y", "d. MMMM y"
+	case discordgo.Polish:
+		shortDate, mediumDate, longDate = "d.MM.y", "d MMM y", "d MMMM y"
+	case discordgo.PortugueseBR:
+		shortDate, mediumDate, longDate = "dd/MM/y", "d 'de' MMM 'de' y", "d 'de' MMMM 'de' y"
+	case discordgo.Romanian:
+		shortDate, mediumDate, longDate = "dd.MM.y", "d MMM y", "d MMMM y"
+	case discordgo.Russian:
+		shortDate, mediumDate, longDate = "dd.MM.y", "d MMM y 'г'.", "d MMMM y 'г'."
+	case discordgo.SpanishES:
+		shortDate, mediumDate, longDate = "d/M/yy", "d MMM y", "d 'de' MMMM 'de' y"
+		shortTime, mediumTime = "H:mm", "H:mm:ss"
+	case discordgo.SpanishLATAM:
+		shortDate, mediumDate, longDate = "d/M/yy", "d MMM y", "d 'de' MMMM 'de' y"
+	case discordgo.Swedish:
+		shortDate, mediumDate, longDate = "y-MM-dd", "d MMM y", "d MMMM y"
+	case discordgo.Thai:
+		shortDate, mediumDate, longDate = "d/M/yy", "d MMM y", "d MMMM y"
+		longTime = "H นาฬิกา mm นาที ss วินาที z"
+	case discordgo.Turkish:
+		shortDate, mediumDate, longDate = "d.MM.y", "d MMM y", "d MMMM y"
+	case discordgo.Ukrainian:
+		shortDate, mediumDate, longDate = "dd.MM.yy", "d MMM y 'р'.", "d MMMM y 'р'."
+	case discordgo.Vietnamese:
+		shortDate, mediumDate, longDate = "dd/MM/y", "d MMM, y", "d MMMM, y"
+	default:
+		shortDate, mediumDate, longDate = "M/d/yy", "MMM d, y", "MMMM d, y"
+		shortTime, mediumTime = "h:mm"+narrowNbsp+"a", "h:mm:ss"+narrowNbsp+"a"
+	}
+
+	if longTime == "" {
+		longTime = mediumTime + " z"
+	}
+
+	format.dates = map[string]string{styleShort: shortDate, styleMedium: mediumDate, styleLong: longDate}
+	format.times = map[string]string{styleShort: shortTime, styleMedium: mediumTime, styleLong: longTime}
+	return format
+}
+
+// monthNames returns the CLDR wide and abbreviated month names of locale, in their format
+// context (genitive forms for Slavic languages for instance).
+//
+//nolint:exhaustive,funlen // Locales without any name fall back on the en-US ones, long but readable.
+func monthNames(locale discordgo.Locale) ([12]string, [12]string) {
+	switch locale {
+	case discordgo.EnglishGB:
+		months, shortMonths := monthNames(discordgo.EnglishUS)
+		shortMonths[time.September-1] = "Sept"
+		return months, shortMonths
+	case discordgo.Bulgarian:
+		return [12]string{"януари", "февруари", "март", "април", "май", "юни", "юли", "август", "септември",
+				"октомври", "ноември", "декември"},
+			[12]string{"яну", "фев", "март", "апр", "май", "юни", "юли", "авг", "сеп", "окт", "ное", "дек"}
+	case discordgo.ChineseCN:
+		return [12]string{"一月", "二月", "三月", "四月", "五月", "六月", "七月", "八月", "九月", "十月", "十一月", "十二月"},
+			numberedMonths("", "月")
+	case discordgo.ChineseTW, discordgo.Japanese:
+		return numberedMonths("", "月"), numberedMonths("", "月")
+	case discordgo.Korean:
+		return numberedMonths("", "월"), numberedMonths("", "월")
+	case discordgo.Croatian:
+		return [12]string{"siječnja", "veljače", "ožujka", "travnja", "svibnja", "lipnja", "srpnja", "kolovoza",
+				"rujna", "listopada", "studenoga", "prosinca"},
+			[12]string{"sij", "velj", "ožu", "tra", "svi", "lip", "srp", "kol", "ruj", "lis", "stu", "pro"}
+	case discordgo.Czech:
+		return [12]string{"ledna", "února", "března", "dubna", "května", "června", "července", "srpna", "září",
+				"října", "listopadu", "prosince"},
+			[12]string{"led", "úno", "bře", "dub", "kvě", "čvn", "čvc", "srp", "zář", "říj", "lis", "pro"}
+	case discordgo.Danish:
+		return [12]string{"januar", "februar", "marts", "april", "maj", "juni", "juli", "august", "september",
+				"oktober", "november", "december"},
+			[12]string{"jan.", "feb.", "mar.", "apr.", "maj", "jun.", "jul.", "aug.", "sep.", "okt.", "nov.", "dec."}
+	case discordgo.Dutch:
+		return [12]string{"januari", "februari", "maart", "april", "mei", "juni", "juli", "augustus", "september",
+				"oktober", "november", "december"},
+			[12]string{"jan", "feb", "mrt", "apr", "mei", "jun", "jul", "aug", "sep", "okt", "nov", "dec"}
+	case discordgo.Finnish:
+		return [12]string{"tammikuuta", "helmikuuta", "maaliskuuta", "huhtikuuta", "toukokuuta", "kesäkuuta",
+				"heinäkuuta", "elokuuta", "syyskuuta", "lokakuuta", "marraskuuta", "joulukuuta"},
+			[12]string{"tammik.", "helmik.", "maalisk.", "huhtik.", "toukok.", "kesäk.", "heinäk.", "elok.",
+				"syysk.", "lokak.", "marrask.", "jouluk."}
+	case discordgo.French:
+		return [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre",
+				"octobre", "novembre", "décembre"},
+			[12]string{"janv.", "févr.", "mars", "avr.", "mai", "juin", "juil.", "août", "sept.", "oct.", "nov.",
+				"déc."}
+	case discordgo.German:
+		return [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September",
+				"Oktober", "November", "Dezember"},
+			[12]string{"Jan.", "Feb.", "März", "Apr.", "Mai", "Juni", "Juli", "Aug.", "Sept.", "Okt.", "Nov.",
+				"Dez."}
+	case discordgo.Greek:
+		return [12]string{"Ιανουαρίου", "Φεβρουαρίου", "Μαρτίου", "Απριλίου", "Μαΐου", "Ιουνίου", "Ιουλίου",
+				"Αυγούστου", "Σεπτεμβρίου", "Οκτωβρίου", "Νοεμβρίου", "Δεκεμβρίου"},
+			[12]string{"Ιαν", "Φεβ", "Μαρ", "Απρ", "Μαΐ", "Ιουν", "Ιουλ", "Αυγ", "Σεπ", "Οκτ", "Νοε", "Δεκ"}
+	case discordgo.Hindi:
+		return [12]string{"जनवरी", "फ़रवरी", "मार्च", "अप्रैल", "मई", "जून", "जुलाई", "अगस्त", "सितंबर", "अक्तूबर",
+				"नवंबर", "दिसंबर"},
+			[12]string{"जन॰", "फ़र॰", "मार्च", "अप्रैल", "मई", "जून", "जुल॰", "अग॰", "सित॰", "अक्तू॰", "नव॰", "दिस॰"}
+	case discordgo.Hungarian:
+		return [12]string{"január", "február", "március", "április", "május", "június", "július", "augusztus",
+				"szeptember", "október", "november", "december"},
+			[12]string{"jan.", "febr.", "márc.", "ápr.", "máj.", "jún.", "júl.", "aug.", "szept.", "okt.", "nov.",
+				"dec."}
+	case discordgo.Italian:
+		return [12]string{"gennaio", "febbraio", "marzo", "aprile", "maggio", "giugno", "luglio", "agosto",
+				"settembre", "ottobre", "novembre", "dicembre"},
+			[12]string{"gen", "feb", "mar", "apr", "mag", "giu", "lug", "ago", "set", "ott", "nov", "dic"}
+	case discordgo.Lithuanian:
+		return [12]string{"sausio", "vasario", "kovo", "balandžio", "gegužės", "birželio", "liepos", "rugpjūčio",
+				"rugsėjo", "spalio", "lapkričio", "gruodžio"},
+			[12]string{"saus.", "vas.", "kov.", "bal.", "geg.", "birž.", "liep.", "rugp.", "rugs.", "spal.",
+				"lapkr.", "gruod."}
+	case discordgo.Norwegian:
+		return [12]string{"januar", "februar", "mars", "april", "mai", "juni", "juli", "august", "september",
+				"oktober", "november", "desember"},
+			[12]string{"jan.", "feb.", "mar.", "apr.", "mai", "jun.", "jul.", "aug.", "sep.", "okt.", "nov.", "des."}
+	case discordgo.Polish:
+		return [12]string{"stycznia", "lutego", "marca", "kwietnia", "maja", "czerwca", "lipca", "sierpnia",
+				"września", "października", "listopada", "grudnia"},
+			[12]string{"sty", "lut", "mar", "kwi", "maj", "cze", "lip", "sie", "wrz", "paź", "lis", "gru"}
+	case discordgo.PortugueseBR:
+		return [12]string{"janeiro", "fevereiro", "março", "abril", "maio", "junho", "julho", "agosto", "setembro",
+				"outubro", "novembro", "dezembro"},
+			[12]string{"jan.", "fev.", "mar.", "abr.", "mai.", "jun.", "jul.", "ago.", "set.", "out.", "nov.", "dez."}
+	case discordgo.Romanian:
+		return [12]string{"ianuarie", "februarie", "martie", "aprilie", "mai", "iunie", "iulie", "august",
+				"septembrie", "octombrie", "noiembrie", "decembrie"},
+			[12]string{"ian.", "feb.", "mar.", "apr.", "mai", "iun.", "iul.", "aug.", "sept.", "oct.", "nov.", "dec."}
+	case discordgo.Russian:
+		return [12]string{"января", "февраля", "марта", "апреля", "мая", "июня", "июля", "августа", "сентября",
+				"октября", "ноября", "декабря"},
+			[12]string{"янв.", "февр.", "мар.", "апр.", "мая", "июн.", "июл.", "авг.", "сент.", "окт.", "нояб.",
+				"дек."}
+	case discordgo.SpanishES, discordgo.SpanishLATAM:
+		return [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre",
+				"octubre", "noviembre", "diciembre"},
+			[12]string{"ene", "feb", "mar", "abr", "may", "jun", "jul", "ago", "sept", "oct", "nov", "dic"}
+	case discordgo.Swedish:
+		return [12]string{"januari", "februari", "mars", "april", "maj", "juni", "juli", "augusti", "september",
+				"oktober", "november", "december"},
+			[12]string{"jan.", "feb.", "mars", "apr.", "maj", "juni", "juli", "aug.", "sep.", "okt.", "nov.", "dec."}
+	case discordgo.Thai:
+		return [12]string{"มกราคม", "กุมภาพันธ์", "มีนาคม", "เมษายน", "พฤษภาคม", "มิถุนายน", "กรกฎาคม", "สิงหาคม",
+				"กันยายน", "ตุลาคม", "พฤศจิกายน", "ธันวาคม"},
+			[12]string{"ม.ค.", "ก.พ.", "มี.ค.", "เม.ย.", "พ.ค.", "มิ.ย.", "ก.ค.", "ส.ค.", "ก.ย.", "ต.ค.", "พ.ย.", "ธ.ค."}
+	case discordgo.Turkish:
+		return [12]string{"Ocak", "Şubat", "Mart", "Nisan", "Mayıs", "Haziran", "Temmuz", "Ağustos", "Eylül", "Ekim",
+				"Kasım", "Aralık"},
+			[12]string{"Oca", "Şub", "Mar", "Nis", "May", "Haz", "Tem", "Ağu", "Eyl", "Eki", "Kas", "Ara"}
+	case discordgo.Ukrainian:
+		return [12]string{"січня", "лютого", "березня", "квітня", "травня", "червня", "липня", "серпня", "вересня",
+				"жовтня", "листопада", "грудня"},
+			[12]string{"січ.", "лют.", "бер.", "квіт.", "трав.", "черв.", "лип.", "серп.", "вер.", "жовт.", "лист.",
+				"груд."}
+	case discordgo.Vietnamese:
+		return numberedMonths("tháng ", ""), numberedMonths("thg ", "")
+	default:
+		return [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September",
+				"October", "November", "December"},
+			[12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"}
+	}
+}
+
+// numberedMonths returns month names made of their number surrounded by prefix and suffix.
+func numberedMonths(prefix, suffix string) [12]string {
+	var months [12]string
+	for i := range months {
+		months[i] = prefix + strconv.Itoa(i+1) + suffix
+	}
+
+	return months
+}
+
+// durationUnits returns the CLDR long unit patterns of locale, indexed by durationUnit, and
+// the separator between units; unknown locales are formatted as en-US.
+//
+//nolint:exhaustive,funlen // Locales without any unit fall back on the en-US ones, long but readable.
+func durationUnits(locale discordgo.Locale) ([unitCount]unitPattern, string) {
+	switch locale {
+	case discordgo.Bulgarian:
+		return [unitCount]unitPattern{
+			oneOther("{0} ден", "{0} дни"), oneOther("{0} час", "{0} часа"),
+			oneOther("{0} минута", "{0} минути"), oneOther("{0} секунда", "{0} секунди"),
+		}, " "
+	case discordgo.ChineseCN:
+		return [unitCount]unitPattern{
+			invariant("{0}天"), invariant("{0}小时"), invariant("{0}分钟"), invariant("{0}秒钟"),
+		}, ""
+	case discordgo.ChineseTW:
+		return [unitCount]unitPattern{
+			invariant("{0} 天"), invariant("{0} 小時"), invariant("{0} 分鐘"), invariant("{0} 秒"),
+		}, " "
+	case discordgo.Croatian:
+		return [unitCount]unitPattern{
+			oneFewOther("{0} dan", "{0} dana", "{0} dana"), oneFewOther("{0} sat", "{0} sata", "{0} sati"),
+			oneFewOther("{0} minuta", "{0} minute", "{0} minuta"),
+			oneFewOther("{0} sekunda", "{0} sekunde", "{0} sekundi"),
+		}, " "
+	case discordgo.Czech:
+		return [unitCount]unitPattern{
+			oneFewManyOther("{0} den", "{0} dny", "{0} dne", "{0} dní"),
+			oneFewManyOther("{0} hodina", "{0} hodiny", "{0} hodiny", "{0} hodin"),
+			oneFewManyOther("{0} minuta", "{0} minuty", "{0} minuty", "{0} minut"),
+			oneFewManyOther("{0} sekunda", "{0} sekundy", "{0} sekundy", "{0} sekund"),
+		}, " "
+	case discordgo.Danish:
+		return [unitCount]unitPattern{
+			oneOther("{0} dag", "{0} dage"), oneOther("{0} time", "{0} timer"),
+			oneOther("{0} minut", "{0} minutter"), oneOther("{0} sekund", "{0} sekunder"),
+		}, " "
+	case discordgo.Dutch:
+		return [unitCount]unitPattern{
+			oneOther("{0} dag", "{0} dagen"), invariant("{0} uur"),
+			oneOther("{0} minuut", "{0} minuten"), oneOther("{0} seconde", "{0} seconden"),
+		}, " "
+	case discordgo.Finnish:
+		return [unitCount]unitPattern{
+			oneOther("{0} päivä", "{0} päivää"), oneOther("{0} tunti", "{0} tuntia"),
+			oneOther("{0} minuutti", "{0} minuuttia"), oneOther("{0} sekunti", "{0} sekuntia"),
+		}, " "
+	case discordgo.French:
+		return [unitCount]unitPattern{
+			oneOther("{0} jour", "{0} jours"), oneOther("{0} heure", "{0} heures"),
+			oneOther("{0} minute", "{0} minutes"), oneOther("{0} seconde", "{0} secondes"),
+		}, " "
+	case discordgo.German:
+		return [unitCount]unitPattern{
+			oneOther("{0} Tag", "{0} Tage"), oneOther("{0} Stunde", "{0} Stunden"),
+			oneOther("{0} Minute", "{0} Minuten"), oneOther("{0} Sekunde", "{0} Sekunden"),
+		}, " "
+	case discordgo.Greek:
+		return [unitCount]unitPattern{
+			oneOther("{0} ημέρα", "{0} ημέρες"), oneOther("{0} ώρα", "{0} ώρες"),
+			oneOther("{0} λεπτό", "{0} λεπτά"), oneOther("{0} δευτερόλεπτο", "{0} δευτερόλεπτα"),
+		}, " "
+	case discordgo.Hindi:
+		return [unitCount]unitPattern{
+			invariant("{0} दिन"), oneOther("{0} घंटा", "{0} घंटे"), invariant("{0} मिनट"), invariant("{0} सेकंड"),
+		}, " "
+	case discordgo.Hungarian:
+		return [unitCount]unitPattern{
+			invariant("{0} nap"), invariant("{0} óra"), invariant("{0} perc"), invariant("{0} másodperc"),
+		}, " "
+	case discordgo.Italian:
+		return [unitCount]unitPattern{
+			oneOther("{0} giorno", "{0} giorni"), oneOther("{0} ora", "{0} ore"),
+			oneOther("{0} minuto", "{0} minuti"), oneOther("{0} secondo", "{0} secondi"),
+		}, " "
+	case discordgo.Japanese:
+		return [unitCount]unitPattern{
+			invariant("{0} 日"), invariant("{0} 時間"), invariant("{0} 分"), invariant("{0} 秒"),
+		}, " "
+	case discordgo.Korean:
+		return [unitCount]unitPattern{
+			invariant("{0}일"), invariant("{0}시간"), invariant("{0}분"), invariant("{0}초"),
+		}, " "
+	case discordgo.Lithuanian:
+		return [unitCount]unitPattern{
+			oneFewManyOther("{0} diena", "{0} dienos", "{0} dienos", "{0} dienų"),
+			oneFewManyOther("{0} valanda", "{0} valandos", "{0} valandos", "{0} valandų"),
+			oneFewManyOther("{0} minutė", "{0} minutės", "{0} minutės", "{0} minučių"),
+			oneFewManyOther("{0} sekundė", "{0} sekundės", "{0} sekundės", "{0} sekundžių"),
+		}, " "
+	case discordgo.Norwegian:
+		return [unitCount]unitPattern{
+			oneOther("{0} døgn", "{0} døgn"), oneOther("{0} time", "{0} timer"),
+			oneOther("{0} minutt", "{0} minutter"), oneOther("{0} sekund", "{0} sekunder"),
+		}, " "
+	case discordgo.Polish:
+		return [unitCount]unitPattern{
+			oneFewManyOther("{0} dzień", "{0} dni", "{0} dni", "{0} dnia"),
+			oneFewManyOther("{0} godzina", "{0} godziny", "{0} godzin", "{0} godziny"),
+			oneFewManyOther("{0} minuta", "{0} minuty", "{0} minut", "{0} minuty"),
+			oneFewManyOther("{0} sekunda", "{0} sekundy", "{0} sekund", "{0} sekundy"),
+		}, " "
+	case discordgo.PortugueseBR:
+		return [unitCount]unitPattern{
+			oneOther("{0} dia", "{0} dias"), oneOther("{0} hora", "{0} horas"),
+			oneOther("{0} minuto", "{0} minutos"), oneOther("{0} segundo", "{0} segundos"),
+		}, " "
+	case discordgo.Romanian:
+		return [unitCount]unitPattern{
+			oneFewOther("{0} zi", "{0} zile", "{0} de zile"), oneFewOther("{0} oră", "{0} ore", "{0} de ore"),
+			oneFewOther("{0} minut", "{0} minute", "{0} de minute"),
+			oneFewOther("{0} secundă", "{0} secunde", "{0} de secunde"),
+		}, " "
+	case discordgo.Russian:
+		return [unitCount]unitPattern{
+			oneFewManyOther("{0} день", "{0} дня", "{0} дней", "{0} дня"),
+			oneFewManyOther("{0} час", "{0} часа", "{0} часов", "{0} часа"),
+			oneFewManyOther("{0} минута", "{0} минуты", "{0} минут", "{0} минуты"),
+			oneFewManyOther("{0} секунда", "{0} секунды", "{0} секунд", "{0} секунды"),
+		}, " "
+	case discordgo.SpanishES, discordgo.SpanishLATAM:
+		return [unitCount]unitPattern{
+			oneOther("{0} día", "{0} días"), oneOther("{0} hora", "{0} horas"),
+			oneOther("{0} minuto", "{0} minutos"), oneOther("{0} segundo", "{0} segundos"),
+		}, " "
+	case discordgo.Swedish:
+		return [unitCount]unitPattern{
+			invariant("{0} dygn"), oneOther("{0} timme", "{0} timmar"),
+			oneOther("{0} minut", "{0} minuter"), oneOther("{0} sekund", "{0} sekunder"),
+		}, " "
+	case discordgo.Thai:
+		return [unitCount]unitPattern{
+			invariant("{0} วัน"), invariant("{0} ชั่วโมง"), invariant("{0} นาที"), invariant("{0} วินาที"),
+		}, " "
+	case discordgo.Turkish:
+		return [unitCount]unitPattern{
+			invariant("{0} gün"), invariant("{0} saat"), invariant("{0} dakika"), invariant("{0} saniye"),
+		}, " "
+	case discordgo.Ukrainian:
+		return [unitCount]unitPattern{
+			oneFewManyOther("{0} день", "{0} дні", "{0} днів", "{0} дня"),
+			oneFewManyOther("{0} година", "{0} години", "{0} годин", "{0} години"),
+			oneFewManyOther("{0} хвилина", "{0} хвилини", "{0} хвилин", "{0} хвилини"),
+			oneFewManyOther("{0} секунда", "{0} секунди", "{0} секунд", "{0} секунди"),
+		}, " "
+	case discordgo.Vietnamese:
+		return [unitCount]unitPattern{
+			invariant("{0} ngày"), invariant("{0} giờ"), invariant("{0} phút"), invariant("{0} giây"),
+		}, " "
+	default:
+		return [unitCount]unitPattern{
+			oneOther("{0} day", "{0} days"), oneOther("{0} hour", "{0} hours"),
+			oneOther("{0} minute", "{0} minutes"), oneOther("{0} second", "{0} seconds"),
+		}, " "
+	}
+}
+
+func invariant(other string) unitPattern {
+	return unitPattern{pluralOther: other}
+}
+
+func oneOther(one, other string) unitPattern {
+	return unitPattern{pluralOne: one, pluralOther: other}
+}
+
+func oneFewOther(one, few, other string) unitPattern {
+	return unitPattern{pluralOne: one, pluralFew: few, pluralOther: other}
+}
+
+func oneFewManyOther(one, few, many, other string) unitPattern {
+	return unitPattern{pluralOne: one, pluralFew: few, pluralMany: many, pluralOther: other}
+}
+
+// formatDate formats value, a time.Time or unix timestamp, with the given date style.
+func (format dateTimeFormat) formatDate(value any, style string) (string, error) {
+	return format.formatStyle(format.dates, value, style)
+}
+
+// formatTime formats value, a time.Time or unix timestamp, with the given time style.
+func (format dateTimeFormat) formatTime(value any, style string) (string, error) {
+	return format.formatStyle(format.times, value, style)
+}
+
+func (format dateTimeFormat) formatStyle(patterns map[string]string, value any, style string) (string, error) {
+	pattern, found := patterns[style]
+	if !found {
+		return "", fmt.Errorf("unsupported style '%s', expected '%s', '%s' or '%s'",
+			style, styleShort, styleMedium, styleLong)
+	}
+
+	date, err := toTime(value)
+	if err != nil {
+		return "", err
+	}
+
+	return format.formatPattern(pattern, date), nil
+}
+
+// formatPattern formats date with a CLDR date pattern, see
+// https://unicode.org/reports/tr35/tr35-dates.html#Date_Field_Symbol_Table.
+// Only the fields used by the embedded patterns are supported, others are written as is.
+func (format dateTimeFormat) formatPattern(pattern string, date time.Time) string {
+	var buf strings.Builder
+	runes := []rune(pattern)
+	for i := 0; i < len(runes); {
+		if runes[i] == patternQuote {
+			i = writeQuoted(&buf, runes, i)
+			continue
+		}
+
+		field := runes[i]
+		width := 1
+		for i+width < len(runes) && runes[i+width] == field {
+			width++
+		}
+		i += width
+
+		switch field {
+		case 'y':
+			if width == twoDigits {
+				buf.WriteString(padDigits(date.Year()%centuryModulo, twoDigits))
+			} else {
+				buf.WriteString(padDigits(date.Year(), width))
+			}
+		case 'M':
+			switch {
+			case width >= fullMonthName:
+				buf.WriteString(format.months[date.Month()-1])
+			case width == shortMonthName:
+				buf.WriteString(format.shortMonths[date.Month()-1])
+			default:
+				buf.WriteString(padDigits(int(date.Month()), width))
+			}
+		case 'd':
+			buf.WriteString(padDigits(date.Day(), width))
+		case 'H':
+			buf.WriteString(padDigits(date.Hour(), width))
+		case 'h':
+			hour := date.Hour() % hoursPerPeriod
+			if hour == 0 {
+				hour = hoursPerPeriod
+			}
+			buf.WriteString(padDigits(hour, width))
+		case 'm':
+			buf.WriteString(padDigits(date.Minute(), width))
+		case 's':
+			buf.WriteString(padDigits(date.Second(), width))
+		case 'a':
+			buf.WriteString(format.periods[date.Hour()/hoursPerPeriod])
+		case 'z':
+			buf.WriteString(zoneName(date))
+		default:
+			buf.WriteString(strings.Repeat(string(field), width))
+		}
+	}
+
+	return buf.String()
+}
+
+// zoneName returns the abbreviated name of the location of date, or its offset from GMT such as
+// GMT+5:30 when the location has no name.
+func zoneName(date time.Time) string {
+	name, offset := date.Zone()
+	if name != "" && name[0] != '+' && name[0] != '-' {
+		return name
+	}
+
+	if offset == 0 {
+		return "GMT"
+	}
+
+	sign := '+'
+	if offset < 0 {
+		sign, offset = '-', -offset
+	}
+	zone := fmt.Sprintf("GMT%c%d", sign, offset/secondsPerHour)
+	if minutes := offset % secondsPerHour / secondsPerMinute; minutes != 0 {
+		zone += ":" + padDigits(minutes, twoDigits)
+	}
+	return zone
+}
+
+// writeQuoted writes the literal text starting with the quote at position start and returns
+// the position following the closing quote.
+func writeQuoted(buf *strings.Builder, runes []rune, start int) int {
+	if start+1 < len(runes) && runes[start+1] == patternQuote {
+		buf.WriteRune(patternQuote)
+		return start + twoDigits
+	}
+
+	i := start + 1
+	for ; i < len(runes); i++ {
+		if runes[i] != patternQuote {
+			buf.WriteRune(runes[i])
+			continue
+		}
+
+		if i+1 < len(runes) && runes[i+1] == patternQuote {
+			buf.WriteRune(patternQuote)
+			i++
+			continue
+		}
+
+		return i + 1
+	}
+
+	return i
+}
+
+func padDigits(value, width int) string {
+	digits := strconv.Itoa(value)
+	if len(digits) < width {
+		digits = strings.Repeat("0", width-len(digits)) + digits
+	}
+
+	return digits
+}
+
+// formatDuration formats value, a time.Duration or a number of seconds, as a list of days,
+// hours, minutes and seconds; zero units are skipped and at most maxUnits units are written
+// when maxUnits is positive. The sign and fractions of seconds are ignored.
+func formatDuration(locale discordgo.Locale, format numberFormat, value any, maxUnits int) (string, error) {
+	duration, err := toDuration(value)
+	if err != nil {
+		return "", err
+	}
+
+	seconds := duration / time.Second
+	if seconds < 0 {
+		seconds = -seconds
+	}
+
+	amounts := [unitCount]int64{
+		unitDay:    int64(seconds / (hoursPerDay * time.Hour / time.Second)),
+		unitHour:   int64(seconds / (time.Hour / time.Second) % hoursPerDay),
+		unitMinute: int64(seconds / (time.Minute / time.Second) % (time.Hour / time.Minute)),
+		unitSecond: int64(seconds % (time.Minute / time.Second)),
+	}
+
+	units, separator := durationUnits(locale)
+	rule := cardinalRule(locale)
+	if _, found := discordgo.Locales[locale]; !found || locale == discordgo.Unknown {
+		// Unknown locales are formatted with en-US units, so with its rules as well
+		rule = cardinalRule(defaultLocale)
+	}
+	parts := make([]string, 0, unitCount)
+	for unit, amount := range amounts {
+		if amount == 0 || (maxUnits > 0 && len(parts) == maxUnits) {
+			continue
+		}
+
+		part, err := formatUnit(units[unit], rule, format, amount)
+		if err != nil {
+			return "", err
+		}
+		parts = append(parts, part)
+	}
+
+	if len(parts) == 0 {
+		return formatUnit(units[unitSecond], rule, format, 0)
+	}
+
+	return strings.Join(parts, separator), nil
+}
+
+func formatUnit(pattern unitPattern, rule pluralRule, format numberFormat, amount int64) (string, error) {
+	operands, err := newPluralOperands(amount)
+	if err != nil {
+		return "", err
+	}
+
+	unitPattern, found := pattern[rule(operands)]
+	if !found {
+		unitPattern = pattern[pluralOther]
+	}
+
+	formatted, err := format.formatNumber(amount, 0, 0)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.Replace(unitPattern, unitPlaceholder, formatted, 1), nil
+}
+
+// toTime converts a time.Time, *time.Time or integer unix timestamp in seconds to time.Time;
+// timestamps are expressed in UTC.
+func toTime(value any) (time.Time, error) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, nil
+	case *time.Time:
+		if v == nil {
+			return time.Time{}, fmt.Errorf("time cannot be nil")
+		}
+		return *v, nil
+	default:
+		seconds, err := toInteger(value)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("'%v' of type %T is neither a time nor a unix timestamp", value, value)
+		}
+		return time.Unix(seconds, 0).UTC(), nil
+	}
+}
+
+// toDuration converts a time.Duration or an integer number of seconds to time.Duration.
+func toDuration(value any) (time.Duration, error) {
+	if duration, isDuration := value.(time.Duration); isDuration {
+		return duration, nil
+	}
+
+	seconds, err := toInteger(value)
+	if err != nil {
+		return 0, fmt.Errorf("'%v' of type %T is neither a duration nor a number of seconds", value, value)
+	}
+
+	if seconds > math.MaxInt64/int64(time.Second) || seconds < math.MinInt64/int64(time.Second) {
+		return 0, fmt.Errorf("%d seconds overflow a duration", seconds)
+	}
+
+	return time.Duration(seconds) * time.Second, nil
+}
+
+// toInteger converts any integer to int64.
+func toInteger(value any) (int64, error) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), nil
+	case int8:
+		return int64(v), nil
+	case int16:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case uint:
+		if uint64(v) > math.MaxInt64 {
+			return 0, fmt.Errorf("'%d' overflows int64", v)
+		}
+		return int64(v), nil
+	case uint8:
+		return int64(v), nil
+	case uint16:
+		return int64(v), nil
+	case uint32:
+		return int64(v), nil
+	case uint64:
+		if v > math.MaxInt64 {
+			return 0, fmt.Errorf("'%d' overflows int64", v)
+		}
+		return int64(v), nil
+	default:
+		return 0, fmt.Errorf("'%v' of type %T is not an integer", value, value)
+	}
+}
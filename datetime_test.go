@@ -0,0 +1,222 @@
+package discordgoi18n
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test formatting dates in every Discord locale
+func TestFormatDate(t *testing.T) {
+	expected := map[discordgo.Locale]string{
+		discordgo.EnglishUS:    "March 5, 2024",
+		discordgo.EnglishGB:    "5 March 2024",
+		discordgo.Bulgarian:    "5 март 2024 г.",
+		discordgo.ChineseCN:    "2024年3月5日",
+		discordgo.ChineseTW:    "2024年3月5日",
+		discordgo.Croatian:     "5. ožujka 2024.",
+		discordgo.Czech:        "5. března 2024",
+		discordgo.Danish:       "5. marts 2024",
+		discordgo.Dutch:        "5 maart 2024",
+		discordgo.Finnish:      "5. maaliskuuta 2024",
+		discordgo.French:       "5 mars 2024",
+		discordgo.German:       "5. März 2024",
+		discordgo.Greek:        "5 Μαρτίου 2024",
+		discordgo.Hindi:        "5 मार्च 2024",
+		discordgo.Hungarian:    "2024. március 5.",
+		discordgo.Italian:      "5 marzo 2024",
+		discordgo.Japanese:     "2024年3月5日",
+		discordgo.Korean:       "2024년 3월 5일",
+		discordgo.Lithuanian:   "2024 m. kovo 5 d.",
+		discordgo.Norwegian:    "5. mars 2024",
+		discordgo.Polish:       "5 marca 2024",
+		discordgo.PortugueseBR: "5 de março de 2024",
+		discordgo.Romanian:     "5 martie 2024",
+		discordgo.Russian:      "5 марта 2024 г.",
+		discordgo.SpanishES:    "5 de marzo de 2024",
+		discordgo.SpanishLATAM: "5 de marzo de 2024",
+		discordgo.Swedish:      "5 mars 2024",
+		discordgo.Thai:         "5 มีนาคม 2024",
+		discordgo.Turkish:      "5 Mart 2024",
+		discordgo.Ukrainian:    "5 березня 2024 р.",
+		discordgo.Vietnamese:   "5 tháng 3, 2024",
+		discordgo.Unknown:      "March 5, 2024",
+	}
+
+	date := time.Date(2024, time.March, 5, 14, 7, 9, 0, time.UTC)
+	for locale := range discordgo.Locales {
+		formatted, err := newDateTimeFormat(locale).formatDate(date, styleLong)
+		assert.NoError(t, err)
+		assert.Equal(t, expected[locale], formatted, locale)
+	}
+}
+
+// Test formatting dates and times with every style
+func TestFormatDateTimeStyles(t *testing.T) {
+	date := time.Date(2024, time.September, 5, 9, 7, 3, 0, time.UTC)
+	for _, test := range []struct {
+		locale   discordgo.Locale
+		date     bool
+		style    string
+		expected string
+	}{
+		{discordgo.EnglishUS, true, styleShort, "9/5/24"},
+		{discordgo.EnglishUS, true, styleMedium, "Sep 5, 2024"},
+		{discordgo.EnglishGB, true, styleMedium, "5 Sept 2024"},
+		{discordgo.French, true, styleShort, "05/09/2024"},
+		{discordgo.French, true, styleMedium, "5 sept. 2024"},
+		{discordgo.PortugueseBR, true, styleMedium, "5 de set. de 2024"},
+		{discordgo.EnglishUS, false, styleShort, "9:07 AM"},
+		{discordgo.EnglishUS, false, styleMedium, "9:07:03 AM"},
+		{discordgo.EnglishUS, false, styleLong, "9:07:03 AM UTC"},
+		{discordgo.French, false, styleLong, "09:07:03 UTC"},
+		{discordgo.ChineseCN, false, styleLong, "UTC 09:07:03"},
+		{discordgo.Croatian, false, styleLong, "09:07:03 (UTC)"},
+		{discordgo.Korean, false, styleLong, "오전 9시 7분 3초 UTC"},
+		{discordgo.French, false, styleShort, "09:07"},
+		{discordgo.Danish, false, styleMedium, "09.07.03"},
+		{discordgo.Korean, false, styleShort, "오전 9:07"},
+		{discordgo.ChineseTW, false, styleShort, "上午9:07"},
+		{discordgo.Bulgarian, false, styleShort, "9:07 ч."},
+	} {
+		format := newDateTimeFormat(test.locale)
+		formatted, err := format.formatTime(date, test.style)
+		if test.date {
+			formatted, err = format.formatDate(date, test.style)
+		}
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, formatted, test)
+	}
+
+	english := newDateTimeFormat(discordgo.EnglishUS)
+	formatted, err := english.formatTime(time.Date(2024, time.March, 5, 0, 30, 0, 0, time.UTC), styleShort)
+	assert.NoError(t, err)
+	assert.Equal(t, "12:30 AM", formatted)
+
+	formatted, err = english.formatTime(time.Date(2024, time.March, 5, 12, 30, 0, 0, time.UTC), styleShort)
+	assert.NoError(t, err)
+	assert.Equal(t, "12:30 PM", formatted)
+
+	formatted, err = english.formatDate(int64(1709647629), styleMedium)
+	assert.NoError(t, err)
+	assert.Equal(t, "Mar 5, 2024", formatted)
+
+	formatted, err = english.formatDate(&date, styleMedium)
+	assert.NoError(t, err)
+	assert.Equal(t, "Sep 5, 2024", formatted)
+
+	for _, value := range []any{"2024-03-05", 1.5, (*time.Time)(nil), nil} {
+		_, err = english.formatDate(value, styleMedium)
+		assert.Error(t, err, value)
+	}
+
+	_, err = english.formatDate(date, "full")
+	assert.Error(t, err)
+}
+
+// Test formatting CLDR date patterns
+func TestFormatPattern(t *testing.T) {
+	date := time.Date(2007, time.January, 2, 3, 4, 5, 0, time.UTC)
+	english := newDateTimeFormat(discordgo.EnglishUS)
+	for pattern, expected := range map[string]string{
+		"y-MM-dd HH:mm:ss": "2007-01-02 03:04:05",
+		"yy/M/d h:m:s a":   "07/1/2 3:4:5 AM",
+		"yyyy":             "2007",
+		"'on' EEEE":        "on EEEE",
+		"'o''clock' h":     "o'clock 3",
+		"h''":              "3'",
+		"'unterminated":    "unterminated",
+	} {
+		assert.Equal(t, expected, english.formatPattern(pattern, date), pattern)
+	}
+
+	for zone, expected := range map[*time.Location]string{
+		time.FixedZone("CET", 3600):           "CET",
+		time.FixedZone("", 0):                 "GMT",
+		time.FixedZone("", 5*3600+30*60):      "GMT+5:30",
+		time.FixedZone("-03", -3*3600):        "GMT-3",
+		time.FixedZone("", -(9*3600 + 30*60)): "GMT-9:30",
+	} {
+		assert.Equal(t, expected, english.formatPattern("z", date.In(zone)), expected)
+	}
+}
+
+// Test formatting durations in every Discord locale
+func TestFormatDuration(t *testing.T) {
+	expected := map[discordgo.Locale]string{
+		discordgo.EnglishUS:    "1 day 2 hours 1 minute 5 seconds",
+		discordgo.EnglishGB:    "1 day 2 hours 1 minute 5 seconds",
+		discordgo.Bulgarian:    "1 ден 2 часа 1 минута 5 секунди",
+		discordgo.ChineseCN:    "1天2小时1分钟5秒钟",
+		discordgo.ChineseTW:    "1 天 2 小時 1 分鐘 5 秒",
+		discordgo.Croatian:     "1 dan 2 sata 1 minuta 5 sekundi",
+		discordgo.Czech:        "1 den 2 hodiny 1 minuta 5 sekund",
+		discordgo.Danish:       "1 dag 2 timer 1 minut 5 sekunder",
+		discordgo.Dutch:        "1 dag 2 uur 1 minuut 5 seconden",
+		discordgo.Finnish:      "1 päivä 2 tuntia 1 minuutti 5 sekuntia",
+		discordgo.French:       "1 jour 2 heures 1 minute 5 secondes",
+		discordgo.German:       "1 Tag 2 Stunden 1 Minute 5 Sekunden",
+		discordgo.Greek:        "1 ημέρα 2 ώρες 1 λεπτό 5 δευτερόλεπτα",
+		discordgo.Hindi:        "1 दिन 2 घंटे 1 मिनट 5 सेकंड",
+		discordgo.Hungarian:    "1 nap 2 óra 1 perc 5 másodperc",
+		discordgo.Italian:      "1 giorno 2 ore 1 minuto 5 secondi",
+		discordgo.Japanese:     "1 日 2 時間 1 分 5 秒",
+		discordgo.Korean:       "1일 2시간 1분 5초",
+		discordgo.Lithuanian:   "1 diena 2 valandos 1 minutė 5 sekundės",
+		discordgo.Norwegian:    "1 døgn 2 timer 1 minutt 5 sekunder",
+		discordgo.Polish:       "1 dzień 2 godziny 1 minuta 5 sekund",
+		discordgo.PortugueseBR: "1 dia 2 horas 1 minuto 5 segundos",
+		discordgo.Romanian:     "1 zi 2 ore 1 minut 5 secunde",
+		discordgo.Russian:      "1 день 2 часа 1 минута 5 секунд",
+		discordgo.SpanishES:    "1 día 2 horas 1 minuto 5 segundos",
+		discordgo.SpanishLATAM: "1 día 2 horas 1 minuto 5 segundos",
+		discordgo.Swedish:      "1 dygn 2 timmar 1 minut 5 sekunder",
+		discordgo.Thai:         "1 วัน 2 ชั่วโมง 1 นาที 5 วินาที",
+		discordgo.Turkish:      "1 gün 2 saat 1 dakika 5 saniye",
+		discordgo.Ukrainian:    "1 день 2 години 1 хвилина 5 секунд",
+		discordgo.Vietnamese:   "1 ngày 2 giờ 1 phút 5 giây",
+		discordgo.Unknown:      "1 day 2 hours 1 minute 5 seconds",
+	}
+
+	duration := 26*time.Hour + time.Minute + 5*time.Second
+	for locale := range discordgo.Locales {
+		formatted, err := formatDuration(locale, newNumberFormat(locale), duration, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, expected[locale], formatted, locale)
+	}
+}
+
+// Test formatting edge cases of durations
+func TestFormatDurationCases(t *testing.T) {
+	english := newNumberFormat(discordgo.EnglishUS)
+	for _, test := range []struct {
+		value    any
+		maxUnits int
+		expected string
+	}{
+		{2*time.Hour + 5*time.Minute, 0, "2 hours 5 minutes"},
+		{2*time.Hour + 5*time.Minute + 3*time.Second, 1, "2 hours"},
+		{26*time.Hour + 5*time.Second, 2, "1 day 2 hours"},
+		{time.Duration(0), 0, "0 seconds"},
+		{1500 * time.Millisecond, 0, "1 second"},
+		{-90 * time.Second, 0, "1 minute 30 seconds"},
+		{90, 0, "1 minute 30 seconds"},
+		{uint8(1), 0, "1 second"},
+		{1500 * 24 * time.Hour, 0, "1,500 days"},
+	} {
+		formatted, err := formatDuration(discordgo.EnglishUS, english, test.value, test.maxUnits)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, formatted, test)
+	}
+
+	formatted, err := formatDuration(discordgo.Russian, newNumberFormat(discordgo.Russian), 21*time.Minute, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "21 минута", formatted)
+
+	for _, value := range []any{"2h", 1.5, nil, int64(1) << 62} {
+		_, err = formatDuration(discordgo.EnglishUS, english, value, 0)
+		assert.Error(t, err, value)
+	}
+}
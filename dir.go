@@ -0,0 +1,144 @@
+package discordgoi18n
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// bundleFile is a bundle file found in a directory along with the locale inferred from its path.
+type bundleFile struct {
+	path   string
+	locale discordgo.Locale
+}
+
+func (translator *translatorImpl) LoadDir(dir string) error {
+	return translator.loadDir(os.DirFS(dir), ".", func(file string) string {
+		return translator.buildCachePath(filepath.Join(dir, filepath.FromSlash(file)), osSource)
+	})
+}
+
+func (translator *translatorImpl) LoadDirFS(fsys fs.FS, dir string) error {
+	return translator.loadDir(fsys, dir, func(file string) string {
+		return translator.buildCachePath(file, fsSource)
+	})
+}
+
+// loadDir loads every bundle file found under dir, the files of a locale being merged in
+// lexical order then stored according to the load mode. A locale is not loaded at all when
+// one of its files fails; every failure is reported in the returned error.
+func (translator *translatorImpl) loadDir(fsys fs.FS, dir string, cachePath func(file string) string) error {
+	files, errs := findBundleFiles(fsys, dir)
+
+	layers := make(map[discordgo.Locale][]bundleLayer)
+	failed := make(map[discordgo.Locale]struct{})
+	for _, file := range files {
+		fileCachePath := cachePath(file.path)
+		layer, err := translator.readBundle(fsys, file.path, fileCachePath)
+		if err != nil {
+			errs = append(errs, err)
+			failed[file.locale] = struct{}{}
+			continue
+		}
+
+		layers[file.locale] = append(layers[file.locale], layer)
+	}
+
+	for _, locale := range slices.Sorted(maps.Keys(layers)) {
+		if _, found := failed[locale]; found {
+			translator.logger.Error().Msgf("Bundles of '%s' not loaded since some of its files cannot be loaded", locale)
+			continue
+		}
+
+		if err := translator.storeBundles(locale, layers[locale]); err != nil {
+			errs = append(errs, fmt.Errorf("cannot load bundles of '%s': %w", locale, err))
+			continue
+		}
+		translator.logger.Debug().Msgf("Bundle '%s' loaded with %d files of '%s'", locale, len(layers[locale]), dir)
+	}
+
+	return errors.Join(errs...)
+}
+
+// readBundle returns the layer of path in fsys, either from cache or decoded from the file.
+// Decoded files are only compiled once stored, along with the partials and constants of the
+// other files of their locale.
+func (translator *translatorImpl) readBundle(fsys fs.FS, file, cachePath string) (bundleLayer, error) {
+	if loadedLayer, found := translator.state.Load().loadedBundles[cachePath]; found {
+		return loadedLayer, nil
+	}
+
+	buf, err := fs.ReadFile(fsys, file)
+	if err != nil {
+		return bundleLayer{}, err
+	}
+
+	content, err := decodeBundle(buf, formatOf(file))
+	if err != nil {
+		return bundleLayer{}, fmt.Errorf("cannot decode bundle '%s': %w", file, err)
+	}
+
+	return bundleLayer{cachePath: cachePath, content: content}, nil
+}
+
+// findBundleFiles walks dir in lexical order and returns the bundle files found along with
+// their locale, files whose locale cannot be inferred being reported as errors.
+func findBundleFiles(fsys fs.FS, dir string) ([]bundleFile, []error) {
+	var (
+		files []bundleFile
+		errs  []error
+	)
+
+	walkErr := fs.WalkDir(fsys, dir, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			errs = append(errs, err)
+			return nil
+		}
+
+		if entry.IsDir() || !isBundleFile(file) {
+			return nil
+		}
+
+		locale, found := inferLocale(relativePath(dir, file))
+		if !found {
+			errs = append(errs, fmt.Errorf("cannot infer locale of '%s'", file))
+			return nil
+		}
+
+		files = append(files, bundleFile{path: file, locale: locale})
+		return nil
+	})
+	if walkErr != nil {
+		errs = append(errs, walkErr)
+	}
+
+	return files, errs
+}
+
+// inferLocale returns the locale named by the first directory of file, such as fr/commands.json
+// or es-ES/commands/errors.json, or by its name for files at the root such as pt-BR.json.
+// Common mistakes are normalized like for any loaded bundle, such as en/ or pt_BR.json.
+func inferLocale(file string) (discordgo.Locale, bool) {
+	name, _, nested := strings.Cut(file, "/")
+	if !nested {
+		name = strings.TrimSuffix(name, path.Ext(name))
+	}
+
+	return parseLocale(name)
+}
+
+func relativePath(dir, file string) string {
+	if dir == "." {
+		return file
+	}
+
+	return strings.TrimPrefix(file, dir+"/")
+}
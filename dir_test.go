@@ -0,0 +1,121 @@
+package discordgoi18n
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test inferring locales from file paths
+func TestInferLocale(t *testing.T) {
+	for file, expected := range map[string]discordgo.Locale{
+		"fr.json":                 discordgo.French,
+		"pt-BR.json":              discordgo.PortugueseBR,
+		"pt_br.JSON":              discordgo.PortugueseBR,
+		"es-ES/commands.json":     discordgo.SpanishES,
+		"fr/commands/errors.json": discordgo.French,
+		"de/en-US.json":           discordgo.German,
+		"zh-TW/hi.json":           discordgo.ChineseTW,
+		"fr-FR.json":              discordgo.French,
+		"hi.json":                 discordgo.Hindi,
+		"fr-FR/commands.json":     discordgo.French,
+		"en/commands.json":        discordgo.EnglishUS,
+		"FR/commands.json":        discordgo.French,
+		"pt_BR/commands.json":     discordgo.PortugueseBR,
+	} {
+		locale, found := inferLocale(file)
+		assert.True(t, found, file)
+		assert.Equal(t, expected, locale, file)
+	}
+
+	// Only the first directory names the locale
+	for _, file := range []string{
+		"commands.json", "french/commands.json", ".json", "xx-YY.json", "help/hi.json", "commands/no.json",
+		"commands/es-419.json", "locales/zh-TW/common.json",
+	} {
+		_, found := inferLocale(file)
+		assert.False(t, found, file)
+	}
+}
+
+// Test loading directories from an FS
+func TestLoadDirFS(t *testing.T) {
+	setUp()
+	defer tearDown()
+
+	fsys := fstest.MapFS{
+		"locales/fr/commands.json": {Data: []byte(`{"ping": "Pong !", "help": "Aide"}`)},
+		"locales/fr/errors.json":   {Data: []byte(`{"error": {"unknown": "Erreur inconnue"}}`)},
+		"locales/pt-BR.json":       {Data: []byte(`{"ping": "Pong!"}`)},
+		"locales/es-ES/a.json":     {Data: []byte(`{"ping": "¡Pong!"}`)},
+		"locales/es-ES/b.yaml":     {Data: []byte("help: Ayuda\n")},
+		"locales/en/commands.json": {Data: []byte(`{"ping": "Pong"}`)},
+		"locales/it-IT/a.json":     {Data: []byte(`{"ping": "Pong"}`)},
+		"locales/README.md":        {Data: []byte(`# Translations`)},
+	}
+	assert.NoError(t, translatorTest.LoadDirFS(fsys, "locales"))
+	assert.Len(t, translatorTest.state.Load().translations, 5)
+	assert.Equal(t, "Pong", translatorTest.Get(discordgo.EnglishUS, "ping", nil))
+	assert.Equal(t, "Pong", translatorTest.Get(discordgo.Italian, "ping", nil))
+	assert.Equal(t, "Pong !", translatorTest.Get(discordgo.French, "ping", nil))
+	assert.Equal(t, "Erreur inconnue", translatorTest.Get(discordgo.French, "error.unknown", nil))
+	assert.Equal(t, "Pong!", translatorTest.Get(discordgo.PortugueseBR, "ping", nil))
+	assert.Equal(t, "¡Pong!", translatorTest.Get(discordgo.SpanishLATAM, "ping", nil))
+	assert.Equal(t, "Ayuda", translatorTest.Get(discordgo.SpanishES, "help", nil))
+
+	// Every failing file is reported and locales with a failing file are not loaded
+	fsys = fstest.MapFS{
+		"fr/commands.json":  {Data: []byte(`{"ping": "Pong !"}`)},
+		"fr/errors.json":    {Data: []byte(`{"ping": "Erreur"}`)},
+		"de/commands.json":  {Data: []byte(`{"ping": "{{ .name"}`)},
+		"it/commands.json":  {Data: []byte(`["ping"]`)},
+		"ja.json":           {Data: []byte(`{"ping": "ポン"}`)},
+		"commands.json":     {Data: []byte(`{"ping": "Pong"}`)},
+		"unknown/list.json": {Data: []byte(`{"ping": "Pong"}`)},
+		"help/hi.json":      {Data: []byte(`{"ping": "पोंग"}`)},
+	}
+	err := translatorTest.LoadDirFS(fsys, ".")
+	assert.Error(t, err)
+	for _, expected := range []string{"'French'", "de/commands.json", "it/commands.json", "'commands.json'", "unknown/list.json",
+		"help/hi.json"} {
+		assert.ErrorContains(t, err, expected)
+	}
+	assert.Equal(t, "Pong !", translatorTest.Get(discordgo.French, "ping", nil))
+	assert.NotContains(t, translatorTest.state.Load().translations, discordgo.German)
+	assert.Equal(t, "ポン", translatorTest.Get(discordgo.Japanese, "ping", nil))
+	assert.NotContains(t, translatorTest.state.Load().translations, discordgo.Hindi)
+
+	assert.Error(t, translatorTest.LoadDirFS(fsys, "missing"))
+}
+
+// Test loading directories from the file system
+func TestLoadDir(t *testing.T) {
+	setUp()
+	defer tearDown()
+
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "fr"), os.ModePerm))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "fr", "commands.json"), []byte(`{"ping": "Pong !"}`), os.ModePerm))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "fr", "errors.json"), []byte(`{"error": "Erreur"}`), os.ModePerm))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "en-GB.json"), []byte(`{"ping": "Pong!"}`), os.ModePerm))
+
+	assert.NoError(t, translatorTest.LoadDir(dir))
+	assert.Equal(t, "Pong !", translatorTest.Get(discordgo.French, "ping", nil))
+	assert.Equal(t, "Erreur", translatorTest.Get(discordgo.French, "error", nil))
+	assert.Equal(t, "Pong!", translatorTest.Get(discordgo.EnglishUS, "ping", nil))
+	assert.Contains(t, translatorTest.state.Load().loadedBundles,
+		translatorTest.buildCachePath(filepath.Join(dir, "fr", "commands.json"), osSource))
+
+	// Directories replace the bundles of their locales unless merged
+	assert.NoError(t, translatorTest.LoadBundleContent(discordgo.French, map[string]any{"extra": "Extra"}))
+	translatorTest.SetLoadMode(LoadModeMerge)
+	assert.NoError(t, translatorTest.LoadDir(dir))
+	assert.Equal(t, "Extra", translatorTest.Get(discordgo.French, "extra", nil))
+	assert.Equal(t, "Pong !", translatorTest.Get(discordgo.French, "ping", nil))
+
+	assert.Error(t, translatorTest.LoadDir(filepath.Join(dir, "missing")))
+}
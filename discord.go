@@ -0,0 +1,165 @@
+package discordgoi18n
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	// Discord timestamp styles, see
+	// https://discord.com/developers/docs/reference#message-formatting-timestamp-styles.
+	timestampShortTime     = "t"
+	timestampLongTime      = "T"
+	timestampShortDate     = "d"
+	timestampLongDate      = "D"
+	timestampShortDateTime = "f"
+	timestampLongDateTime  = "F"
+	timestampRelative      = "R"
+
+	// emojiSeparator separates the animated flag, name and ID of custom emojis.
+	emojiSeparator = ":"
+	animatedEmoji  = "a"
+)
+
+// discordTimestamp returns the Discord markup of value, a time.Time or unix timestamp in
+// seconds, rendered by clients in the viewer's timezone and locale; without style, Discord
+// displays it as a short date and time.
+func discordTimestamp(value any, style ...string) (string, error) {
+	date, err := toTime(value)
+	if err != nil {
+		return "", err
+	}
+
+	switch len(style) {
+	case 0:
+		return fmt.Sprintf("<t:%d>", date.Unix()), nil
+	case 1:
+		switch style[0] {
+		case timestampShortTime, timestampLongTime, timestampShortDate, timestampLongDate,
+			timestampShortDateTime, timestampLongDateTime, timestampRelative:
+			return fmt.Sprintf("<t:%d:%s>", date.Unix(), style[0]), nil
+		default:
+			return "", fmt.Errorf("unsupported timestamp style '%s', expected one of %s", style[0],
+				strings.Join([]string{timestampShortTime, timestampLongTime, timestampShortDate, timestampLongDate,
+					timestampShortDateTime, timestampLongDateTime, timestampRelative}, ", "))
+		}
+	default:
+		return "", fmt.Errorf("at most one style argument expected, got %d", len(style))
+	}
+}
+
+// mention returns the mention markup of a user, member, role, channel or emoji; IDs are
+// mentioned as users.
+func mention(value any) (string, error) {
+	switch v := value.(type) {
+	case *discordgo.User:
+		if v == nil {
+			return "", fmt.Errorf("user cannot be nil")
+		}
+		value = v.ID
+	case *discordgo.Member:
+		if v == nil || v.User == nil {
+			return "", fmt.Errorf("member and its user cannot be nil")
+		}
+		value = v.User.ID
+	case *discordgo.Role:
+		return roleMention(v)
+	case *discordgo.Channel:
+		return channelMention(v)
+	case *discordgo.Emoji:
+		return emojiMarkup(v)
+	}
+
+	id, err := snowflake(value)
+	if err != nil {
+		return "", err
+	}
+
+	return "<@" + id + ">", nil
+}
+
+// channelMention returns the mention markup of a channel or channel ID.
+func channelMention(value any) (string, error) {
+	if channel, isChannel := value.(*discordgo.Channel); isChannel {
+		if channel == nil {
+			return "", fmt.Errorf("channel cannot be nil")
+		}
+		value = channel.ID
+	}
+
+	id, err := snowflake(value)
+	if err != nil {
+		return "", err
+	}
+
+	return "<#" + id + ">", nil
+}
+
+// roleMention returns the mention markup of a role or role ID.
+func roleMention(value any) (string, error) {
+	if role, isRole := value.(*discordgo.Role); isRole {
+		if role == nil {
+			return "", fmt.Errorf("role cannot be nil")
+		}
+		value = role.ID
+	}
+
+	id, err := snowflake(value)
+	if err != nil {
+		return "", err
+	}
+
+	return "<@&" + id + ">", nil
+}
+
+// emojiMarkup returns the markup of an emoji; strings are either Unicode emojis written as
+// is, or custom emojis in their API format "name:id", prefixed with "a:" when animated.
+func emojiMarkup(value any) (string, error) {
+	switch v := value.(type) {
+	case *discordgo.Emoji:
+		if v == nil || v.APIName() == "" {
+			return "", fmt.Errorf("emoji cannot be nil nor empty")
+		}
+		return v.MessageFormat(), nil
+	case string:
+		parts := strings.Split(v, emojiSeparator)
+		switch {
+		case len(parts) == 1 && v != "":
+			return v, nil
+		case len(parts) == 2 && parts[0] != "":
+			if _, err := snowflake(parts[1]); err != nil {
+				return "", err
+			}
+			return "<:" + v + ">", nil
+		case len(parts) == 3 && parts[0] == animatedEmoji && parts[1] != "":
+			if _, err := snowflake(parts[2]); err != nil {
+				return "", err
+			}
+			return "<" + v + ">", nil
+		default:
+			return "", fmt.Errorf("'%s' is neither an emoji nor a custom emoji 'name:id'", v)
+		}
+	default:
+		return "", fmt.Errorf("'%v' of type %T is not an emoji", value, value)
+	}
+}
+
+// snowflake returns the Discord ID held by value, a numeric string or an integer.
+func snowflake(value any) (string, error) {
+	if id, isString := value.(string); isString {
+		if _, err := strconv.ParseUint(id, 10, 64); err != nil {
+			return "", fmt.Errorf("'%s' is not a Discord ID", id)
+		}
+		return id, nil
+	}
+
+	id, err := toInteger(value)
+	if err != nil || id < 0 {
+		return "", fmt.Errorf("'%v' of type %T is not a Discord ID", value, value)
+	}
+
+	return strconv.FormatInt(id, 10), nil
+}
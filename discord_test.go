@@ -0,0 +1,105 @@
+package discordgoi18n
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test formatting Discord timestamps
+func TestDiscordTimestamp(t *testing.T) {
+	date := time.Date(2024, time.March, 5, 14, 7, 9, 0, time.FixedZone("UTC+2", 2*60*60))
+	for _, style := range []string{"t", "T", "d", "D", "f", "F", "R"} {
+		formatted, err := discordTimestamp(date, style)
+		assert.NoError(t, err, style)
+		assert.Equal(t, "<t:1709640429:"+style+">", formatted, style)
+	}
+
+	for _, value := range []any{date, &date, 1709640429, int64(1709640429), uint32(1709640429)} {
+		formatted, err := discordTimestamp(value)
+		assert.NoError(t, err, value)
+		assert.Equal(t, "<t:1709640429>", formatted, value)
+	}
+
+	for _, style := range [][]string{{"x"}, {""}, {"r"}, {"R", "t"}} {
+		_, err := discordTimestamp(date, style...)
+		assert.Error(t, err, style)
+	}
+
+	_, err := discordTimestamp("2024-03-05")
+	assert.Error(t, err)
+}
+
+// Test formatting mentions
+func TestMention(t *testing.T) {
+	user := &discordgo.User{ID: "80351110224678912"}
+	for expected, value := range map[string]any{
+		"<@80351110224678912>":        user,
+		"<@80351110224678913>":        &discordgo.Member{User: &discordgo.User{ID: "80351110224678913"}},
+		"<@&41771983423143936>":       &discordgo.Role{ID: "41771983423143936"},
+		"<#41771983423143937>":        &discordgo.Channel{ID: "41771983423143937"},
+		"<:mmLol:216154654256398347>": &discordgo.Emoji{ID: "216154654256398347", Name: "mmLol"},
+		"<@80351110224678914>":        "80351110224678914",
+		"<@80351110224678915>":        int64(80351110224678915),
+	} {
+		formatted, err := mention(value)
+		assert.NoError(t, err, value)
+		assert.Equal(t, expected, formatted, value)
+	}
+
+	for _, value := range []any{(*discordgo.User)(nil), &discordgo.User{}, &discordgo.Member{}, (*discordgo.Member)(nil),
+		(*discordgo.Role)(nil), "nick", -1, 1.5, nil} {
+		_, err := mention(value)
+		assert.Error(t, err, value)
+	}
+}
+
+// Test formatting channel and role mentions
+func TestChannelRoleMention(t *testing.T) {
+	formatted, err := channelMention(&discordgo.Channel{ID: "41771983423143937"})
+	assert.NoError(t, err)
+	assert.Equal(t, "<#41771983423143937>", formatted)
+
+	formatted, err = channelMention(uint64(41771983423143937))
+	assert.NoError(t, err)
+	assert.Equal(t, "<#41771983423143937>", formatted)
+
+	formatted, err = roleMention(&discordgo.Role{ID: "41771983423143936"})
+	assert.NoError(t, err)
+	assert.Equal(t, "<@&41771983423143936>", formatted)
+
+	formatted, err = roleMention("41771983423143936")
+	assert.NoError(t, err)
+	assert.Equal(t, "<@&41771983423143936>", formatted)
+
+	for _, value := range []any{(*discordgo.Channel)(nil), &discordgo.Role{}, "general", nil} {
+		_, err = channelMention(value)
+		assert.Error(t, err, value)
+		_, err = roleMention(value)
+		assert.Error(t, err, value)
+	}
+}
+
+// Test formatting emojis
+func TestEmojiMarkup(t *testing.T) {
+	for value, expected := range map[any]string{
+		&discordgo.Emoji{ID: "216154654256398347", Name: "mmLol"}:                 "<:mmLol:216154654256398347>",
+		&discordgo.Emoji{ID: "216154654256398348", Name: "dance", Animated: true}: "<a:dance:216154654256398348>",
+		&discordgo.Emoji{Name: "🐶"}:                                               "🐶",
+		"🐱":                                                                       "🐱",
+		"mmLol:216154654256398347":                                                "<:mmLol:216154654256398347>",
+		"a:dance:216154654256398348":                                              "<a:dance:216154654256398348>",
+	} {
+		formatted, err := emojiMarkup(value)
+		assert.NoError(t, err, value)
+		assert.Equal(t, expected, formatted, value)
+	}
+
+	for _, value := range []any{(*discordgo.Emoji)(nil), &discordgo.Emoji{}, "", "mmLol:abc", ":1", "b:dance:1",
+		"a:dance:abc", "a:b:c:d", 216154654256398347} {
+		_, err := emojiMarkup(value)
+		assert.Error(t, err, value)
+	}
+}
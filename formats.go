@@ -0,0 +1,93 @@
+package discordgoi18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// formatOf returns the format of file according to its extension, JSON being the default.
+func formatOf(file string) Format {
+	switch strings.ToLower(path.Ext(file)) {
+	case ".yaml", ".yml":
+		return FormatYAML
+	case ".toml":
+		return FormatTOML
+	default:
+		return FormatJSON
+	}
+}
+
+// isBundleFile reports whether file has the extension of a supported format.
+func isBundleFile(file string) bool {
+	switch strings.ToLower(path.Ext(file)) {
+	case ".json", ".yaml", ".yml", ".toml":
+		return true
+	default:
+		return false
+	}
+}
+
+// decodeBundle decodes buf written in format into bundle content.
+func decodeBundle(buf []byte, format Format) (map[string]any, error) {
+	var content map[string]any
+	switch format {
+	case FormatJSON:
+		if err := json.Unmarshal(buf, &content); err != nil {
+			return nil, err
+		}
+	case FormatYAML:
+		if err := yaml.Unmarshal(buf, &content); err != nil {
+			return nil, err
+		}
+	case FormatTOML:
+		if err := toml.Unmarshal(buf, &content); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown bundle format '%s'", format)
+	}
+
+	return normalizeContent(content), nil
+}
+
+// normalizeContent converts the YAML mappings decoded with non-string keys, such as 1 or true,
+// and the TOML arrays of tables into objects and arrays like JSON ones, so that bundle content
+// is the same whatever its format.
+func normalizeContent(content map[string]any) map[string]any {
+	for key, value := range content {
+		content[key] = normalizeValue(value)
+	}
+
+	return content
+}
+
+func normalizeValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		return normalizeContent(v)
+	case map[any]any:
+		object := make(map[string]any, len(v))
+		for key, subValue := range v {
+			object[fmt.Sprint(key)] = normalizeValue(subValue)
+		}
+		return object
+	case []map[string]any:
+		array := make([]any, len(v))
+		for i, item := range v {
+			array[i] = normalizeContent(item)
+		}
+		return array
+	case []any:
+		for i, item := range v {
+			v[i] = normalizeValue(item)
+		}
+		return v
+	default:
+		return value
+	}
+}
@@ -0,0 +1,108 @@
+package discordgoi18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test detecting bundle formats from file extensions
+func TestFormatOf(t *testing.T) {
+	for file, expected := range map[string]Format{
+		"fr.json":         FormatJSON,
+		"fr.yaml":         FormatYAML,
+		"locales/fr.yml":  FormatYAML,
+		"locales/fr.YAML": FormatYAML,
+		"fr":              FormatJSON,
+		"fr.txt":          FormatJSON,
+		"fr.toml":         FormatTOML,
+		"fr.TOML":         FormatTOML,
+	} {
+		assert.Equal(t, expected, formatOf(file), file)
+	}
+
+	for file, expected := range map[string]bool{
+		"fr.json": true,
+		"fr.yml":  true,
+		"fr.YAML": true,
+		"fr.toml": true,
+		"fr.txt":  false,
+		"fr":      false,
+	} {
+		assert.Equal(t, expected, isBundleFile(file), file)
+	}
+}
+
+// Test decoding YAML bundles
+func TestDecodeBundleYAML(t *testing.T) {
+	content, err := decodeBundle([]byte(`
+# Comments are allowed
+hello: Hello {{ .name }}
+multiline: |
+  First line
+  Second line
+days: [monday, tuesday]
+coins:
+  $plural: cardinal
+  one: "{{ .count }} coin"
+  other: "{{ .count }} coins"
+levels:
+  1: first
+  true: yes
+`), FormatYAML)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"hello":     "Hello {{ .name }}",
+		"multiline": "First line\nSecond line\n",
+		"days":      []any{"monday", "tuesday"},
+		"coins":     map[string]any{"$plural": "cardinal", "one": "{{ .count }} coin", "other": "{{ .count }} coins"},
+		"levels":    map[string]any{"1": "first", "true": "yes"},
+	}, content)
+
+	_, err = decodeBundle([]byte("hello: world\n  bad: indentation\n"), FormatYAML)
+	assert.ErrorContains(t, err, "line 2")
+
+	_, err = decodeBundle([]byte("- hello\n- world\n"), FormatYAML)
+	assert.ErrorContains(t, err, "line 1")
+
+	_, err = decodeBundle([]byte(`{"hello": "world"}`), "xml")
+	assert.Error(t, err)
+}
+
+// Test decoding TOML bundles
+func TestDecodeBundleTOML(t *testing.T) {
+	content, err := decodeBundle([]byte(`
+# Comments are allowed
+hello = "Hello {{ .name }}"
+greetings = ["Hi", "Hey", 'Yo']
+multiline = """
+First line
+Second line"""
+count = 3
+
+[command.ping]
+name = "ping"
+description = "Replies pong"
+
+[coins]
+"$plural" = "cardinal"
+one = "{{ .count }} coin"
+other = "{{ .count }} coins"
+
+[[tips]]
+text = "First tip"
+`), FormatTOML)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"hello":     "Hello {{ .name }}",
+		"greetings": []any{"Hi", "Hey", "Yo"},
+		"multiline": "First line\nSecond line",
+		"count":     int64(3),
+		"command":   map[string]any{"ping": map[string]any{"name": "ping", "description": "Replies pong"}},
+		"coins":     map[string]any{"$plural": "cardinal", "one": "{{ .count }} coin", "other": "{{ .count }} coins"},
+		"tips":      []any{map[string]any{"text": "First tip"}},
+	}, content)
+
+	_, err = decodeBundle([]byte("hello = \"world\"\nbye = \n"), FormatTOML)
+	assert.ErrorContains(t, err, "line 2")
+}
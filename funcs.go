@@ -0,0 +1,233 @@
+package discordgoi18n
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// customFuncs are the template functions registered on a translator, either for every
+// locale or for a single one. Maps are never modified once set, like the translator state.
+type customFuncs struct {
+	global  template.FuncMap
+	locales map[discordgo.Locale]template.FuncMap
+}
+
+// templateFuncs returns the functions available in templates, bound to locale.
+func templateFuncs(locale discordgo.Locale) template.FuncMap {
+	format := newNumberFormat(locale)
+	dateTime := newDateTimeFormat(locale)
+	return template.FuncMap{
+		// number formats a number with locale separators, with up to 3 fraction digits
+		// or exactly the given number of fraction digits.
+		"number": func(value any, fractionDigits ...int) (Markdown, error) {
+			minFraction, maxFraction, err := fractionDigitsOf(0, defaultFractionDigits, fractionDigits)
+			if err != nil {
+				return "", err
+			}
+			return formatted(format.formatNumber(value, minFraction, maxFraction))
+		},
+		// percent formats a ratio as percentage, 0.25 being 25%, optionally with the
+		// given number of fraction digits.
+		"percent": func(value any, fractionDigits ...int) (Markdown, error) {
+			_, maxFraction, err := fractionDigitsOf(0, percentFractionDigits, fractionDigits)
+			if err != nil {
+				return "", err
+			}
+			return formatted(format.formatPercent(value, maxFraction))
+		},
+		// currency formats an amount of the given ISO 4217 currency code.
+		"currency": func(code string, value any) (Markdown, error) {
+			return formatted(format.formatCurrency(code, value))
+		},
+		// date formats a time.Time or unix timestamp as a medium date, or with the given
+		// short, medium or long style.
+		"date": func(value any, style ...string) (Markdown, error) {
+			dateStyle, err := styleOf(style)
+			if err != nil {
+				return "", err
+			}
+			return formatted(dateTime.formatDate(value, dateStyle))
+		},
+		// time formats a time.Time or unix timestamp as a medium time, or with the given
+		// short, medium or long style.
+		"time": func(value any, style ...string) (Markdown, error) {
+			timeStyle, err := styleOf(style)
+			if err != nil {
+				return "", err
+			}
+			return formatted(dateTime.formatTime(value, timeStyle))
+		},
+		// duration formats a time.Duration or number of seconds with every unit, or at most
+		// the given number of units.
+		"duration": func(value any, maxUnits ...int) (Markdown, error) {
+			_, units, err := fractionDigitsOf(0, 0, maxUnits)
+			if err != nil {
+				return "", err
+			}
+			return formatted(formatDuration(locale, format, value, units))
+		},
+		// discordTime formats a time.Time or unix timestamp as Discord timestamp markup,
+		// with an optional t, T, d, D, f, F or R style.
+		"discordTime": func(value any, style ...string) (Markdown, error) {
+			formatted, err := discordTimestamp(value, style...)
+			return Markdown(formatted), err
+		},
+		// mention, channel, role and emoji write the Discord markup of discordgo users,
+		// members, channels, roles and emojis or of their IDs.
+		"mention": markup(mention),
+		"channel": markup(channelMention),
+		"role":    markup(roleMention),
+		"emoji":   markup(emojiMarkup),
+		// t renders another key in the same locale, with the given variables if any.
+		referenceFuncName: unboundReference,
+		// raw and safe inject a value without sanitizing it, escapeMarkdown escapes it
+		// even when escaping is disabled.
+		"raw":            raw,
+		"safe":           raw,
+		"escapeMarkdown": escapeMarkdown,
+		sanitizeFuncName: func(escape, neutralize bool, value any) Markdown {
+			return sanitizer{escapeMarkdown: escape, neutralizeMentions: neutralize}.sanitize(value)
+		},
+	}
+}
+
+// formatted marks the output of a locale formatter as Markdown, so that punctuation such as the
+// dot of German dates is never escaped.
+func formatted(value string, err error) (Markdown, error) {
+	return Markdown(value), err
+}
+
+// fractionDigitsOf returns the optional fraction digits given to a template function
+// as both minimum and maximum, or the default ones.
+func fractionDigitsOf(defaultMin, defaultMax int, fractionDigits []int) (int, int, error) {
+	switch len(fractionDigits) {
+	case 0:
+		return defaultMin, defaultMax, nil
+	case 1:
+		if fractionDigits[0] < 0 {
+			return 0, 0, fmt.Errorf("fraction digits %d cannot be negative", fractionDigits[0])
+		}
+		return fractionDigits[0], fractionDigits[0], nil
+	default:
+		return 0, 0, fmt.Errorf("at most one fraction digits argument expected, got %d", len(fractionDigits))
+	}
+}
+
+// styleOf returns the optional date or time style given to a template function, or the
+// medium one.
+func styleOf(style []string) (string, error) {
+	switch len(style) {
+	case 0:
+		return styleMedium, nil
+	case 1:
+		return style[0], nil
+	default:
+		return "", fmt.Errorf("at most one style argument expected, got %d", len(style))
+	}
+}
+
+// withGlobal returns a copy of custom to which funcs are added for every locale.
+func (custom customFuncs) withGlobal(funcs template.FuncMap) customFuncs {
+	global := maps.Clone(custom.global)
+	if global == nil {
+		global = make(template.FuncMap, len(funcs))
+	}
+	maps.Copy(global, funcs)
+
+	return customFuncs{global: global, locales: custom.locales}
+}
+
+// withLocale returns a copy of custom to which funcs are added for locale only.
+func (custom customFuncs) withLocale(locale discordgo.Locale, funcs template.FuncMap) customFuncs {
+	localeFuncs := maps.Clone(custom.locales[locale])
+	if localeFuncs == nil {
+		localeFuncs = make(template.FuncMap, len(funcs))
+	}
+	maps.Copy(localeFuncs, funcs)
+
+	locales := maps.Clone(custom.locales)
+	if locales == nil {
+		locales = make(map[discordgo.Locale]template.FuncMap)
+	}
+	locales[locale] = localeFuncs
+
+	return customFuncs{global: custom.global, locales: locales}
+}
+
+// parseFuncs returns the functions templates are parsed with: functions registered for some
+// locales only are known, but fail when rendered in other locales.
+func (custom customFuncs) parseFuncs() template.FuncMap {
+	funcs := make(template.FuncMap)
+	for locale, localeFuncs := range custom.locales {
+		for name := range localeFuncs {
+			funcs[name] = func(...any) (any, error) {
+				return nil, fmt.Errorf("function '%s' is only registered for some locales such as '%s'", name, locale)
+			}
+		}
+	}
+
+	maps.Copy(funcs, custom.global)
+	maps.Copy(funcs, templateFuncs(defaultLocale))
+	return funcs
+}
+
+// localizedFuncs returns the functions templates are rendered with in locale.
+func (custom customFuncs) localizedFuncs(locale discordgo.Locale) template.FuncMap {
+	funcs := templateFuncs(locale)
+	if len(custom.global) == 0 && len(custom.locales[locale]) == 0 {
+		return funcs
+	}
+
+	maps.Copy(funcs, custom.global)
+	maps.Copy(funcs, custom.locales[locale])
+	return funcs
+}
+
+// validateFuncs checks funcs can be registered in templates without overriding any built-in
+// function, either from text/template or from this package.
+func validateFuncs(funcs template.FuncMap) error {
+	builtins := templateFuncs(defaultLocale)
+	var collisions []string
+	for name := range funcs {
+		if _, found := builtins[name]; found || slices.Contains(templateBuiltins(), name) {
+			collisions = append(collisions, name)
+		}
+	}
+
+	if len(collisions) > 0 {
+		sort.Strings(collisions)
+		return fmt.Errorf("template functions %s collide with built-in functions", strings.Join(collisions, ", "))
+	}
+
+	return checkFuncs(funcs)
+}
+
+// checkFuncs returns the error text/template panics with when registering funcs with invalid
+// names or signatures.
+func checkFuncs(funcs template.FuncMap) error {
+	var err error
+	func() {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				err = fmt.Errorf("invalid template functions: %v", recovered)
+			}
+		}()
+		template.New("").Funcs(funcs)
+	}()
+
+	return err
+}
+
+// templateBuiltins returns the functions predefined by text/template.
+func templateBuiltins() []string {
+	return []string{
+		"and", "call", "html", "index", "slice", "js", "len", "not", "or", "print", "printf", "println",
+		"urlquery", "eq", "ge", "gt", "le", "lt", "ne",
+	}
+}
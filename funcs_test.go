@@ -0,0 +1,173 @@
+package discordgoi18n
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+)
+
+func executeFuncs(t *testing.T, locale discordgo.Locale, raw string, variables Vars) (string, error) {
+	t.Helper()
+	tmpl, err := template.New("").Funcs(templateFuncs(locale)).Parse(raw)
+	assert.NoError(t, err, raw)
+
+	var buf strings.Builder
+	err = tmpl.Execute(&buf, variables)
+	return buf.String(), err
+}
+
+// Test number template functions
+func TestTemplateFuncsNumbers(t *testing.T) {
+	for raw, expected := range map[string]string{
+		`{{ number .n }}`:            "1\u202f234,568",
+		`{{ number .n 1 }}`:          "1\u202f234,6",
+		`{{ .n | number }}`:          "1\u202f234,568",
+		`{{ percent .ratio }}`:       "43\u202f%",
+		`{{ percent .ratio 2 }}`:     "42,57\u202f%",
+		`{{ currency "EUR" .n }}`:    "1\u202f234,57\u00a0€",
+		`{{ .n | currency "EUR" }}`:  "1\u202f234,57\u00a0€",
+		`{{ number .count }} pièces`: "3 pièces",
+	} {
+		formatted, err := executeFuncs(t, discordgo.French, raw, Vars{"n": 1234.5678, "ratio": 0.4257, "count": 3})
+		assert.NoError(t, err, raw)
+		assert.Equal(t, expected, formatted, raw)
+	}
+
+	for _, raw := range []string{
+		`{{ number .text }}`,
+		`{{ number .n -1 }}`,
+		`{{ number .n 1 2 }}`,
+		`{{ percent .n 1 2 }}`,
+		`{{ currency "EUR" .text }}`,
+	} {
+		_, err := executeFuncs(t, discordgo.French, raw, Vars{"n": 1.5, "text": "abc"})
+		assert.Error(t, err, raw)
+	}
+}
+
+// Test date, time and duration template functions
+func TestTemplateFuncsDateTime(t *testing.T) {
+	variables := Vars{
+		"date":     time.Date(2024, time.March, 5, 14, 7, 9, 0, time.UTC),
+		"unix":     1709647629,
+		"cooldown": 2*time.Hour + 5*time.Minute,
+	}
+
+	for raw, expected := range map[string]string{
+		`{{ date .date }}`:                     "5 mars 2024",
+		`{{ date .date "short" }}`:             "05/03/2024",
+		`{{ date .unix "long" }}`:              "5 mars 2024",
+		`{{ time .date }}`:                     "14:07:09",
+		`{{ time .date "short" }}`:             "14:07",
+		`{{ .date | time }}`:                   "14:07:09",
+		`{{ duration .cooldown }}`:             "2 heures 5 minutes",
+		`{{ duration .cooldown 1 }}`:           "2 heures",
+		`{{ duration 3600 }}`:                  "1 heure",
+		`{{ date .date }} {{ number 1234.5 }}`: "5 mars 2024 1\u202f234,5",
+	} {
+		formatted, err := executeFuncs(t, discordgo.French, raw, variables)
+		assert.NoError(t, err, raw)
+		assert.Equal(t, expected, formatted, raw)
+	}
+
+	for _, raw := range []string{
+		`{{ date "today" }}`,
+		`{{ date .date "full" }}`,
+		`{{ date .date "short" "long" }}`,
+		`{{ time .date "short" "long" }}`,
+		`{{ duration "2h" }}`,
+		`{{ duration .cooldown -1 }}`,
+		`{{ duration .cooldown 1 2 }}`,
+		`{{ discordTime .date "x" }}`,
+	} {
+		_, err := executeFuncs(t, discordgo.French, raw, variables)
+		assert.Error(t, err, raw)
+	}
+}
+
+// Test mention template functions
+func TestTemplateFuncsMentions(t *testing.T) {
+	variables := Vars{
+		"member":  &discordgo.Member{User: &discordgo.User{ID: "80351110224678912"}},
+		"role":    &discordgo.Role{ID: "41771983423143936"},
+		"channel": &discordgo.Channel{ID: "41771983423143937"},
+		"emoji":   &discordgo.Emoji{ID: "216154654256398347", Name: "mmLol"},
+	}
+
+	for raw, expected := range map[string]string{
+		`{{ mention .member }}`:                  "<@80351110224678912>",
+		`{{ mention "80351110224678913" }}`:      "<@80351110224678913>",
+		`{{ mention .role }} {{ role .role }}`:   "<@&41771983423143936> <@&41771983423143936>",
+		`{{ channel .channel }}`:                 "<#41771983423143937>",
+		`{{ emoji .emoji }}`:                     "<:mmLol:216154654256398347>",
+		`{{ emoji "mmLol:216154654256398347" }}`: "<:mmLol:216154654256398347>",
+	} {
+		formatted, err := executeFuncs(t, discordgo.French, raw, variables)
+		assert.NoError(t, err, raw)
+		assert.Equal(t, expected, formatted, raw)
+	}
+
+	for _, raw := range []string{
+		`{{ mention "nick" }}`,
+		`{{ channel .role }}`,
+		`{{ role .channel }}`,
+		`{{ emoji .member }}`,
+	} {
+		_, err := executeFuncs(t, discordgo.French, raw, variables)
+		assert.Error(t, err, raw)
+	}
+}
+
+// Test registering custom template functions
+func TestCustomFuncs(t *testing.T) {
+	custom := customFuncs{}.withGlobal(template.FuncMap{"upper": strings.ToUpper})
+	custom = custom.withLocale(discordgo.French, template.FuncMap{"bot": func() string { return "le bot" }})
+	withGerman := custom.withLocale(discordgo.German, template.FuncMap{"bot": func() string { return "der Bot" }})
+
+	// Copy-on-write: previous sets are not modified
+	assert.Len(t, custom.locales, 1)
+	assert.Len(t, withGerman.locales, 2)
+	assert.Len(t, customFuncs{}.withGlobal(template.FuncMap{"lower": strings.ToLower}).global, 1)
+
+	tmpl, err := template.New("").Funcs(withGerman.parseFuncs()).Parse(`{{ upper .name }} {{ bot }} {{ number 1.5 }}`)
+	assert.NoError(t, err)
+
+	for locale, expected := range map[discordgo.Locale]string{
+		discordgo.French: "NICK le bot 1,5",
+		discordgo.German: "NICK der Bot 1,5",
+	} {
+		var buf strings.Builder
+		localized, _ := tmpl.Clone()
+		assert.NoError(t, localized.Funcs(withGerman.localizedFuncs(locale)).Execute(&buf, Vars{"name": "nick"}))
+		assert.Equal(t, expected, buf.String(), locale)
+	}
+
+	// Functions registered for other locales only fail at rendering
+	localized, _ := tmpl.Clone()
+	err = localized.Funcs(withGerman.localizedFuncs(discordgo.Italian)).Execute(&strings.Builder{}, Vars{"name": "nick"})
+	assert.ErrorContains(t, err, "bot")
+}
+
+// Test validating custom template functions
+func TestValidateFuncs(t *testing.T) {
+	assert.NoError(t, validateFuncs(template.FuncMap{"upper": strings.ToUpper, "join": strings.Join}))
+	assert.NoError(t, validateFuncs(nil))
+
+	err := validateFuncs(template.FuncMap{"number": strings.ToUpper, "t": strings.ToUpper, "printf": fmt.Sprintf, "upper": strings.ToUpper})
+	assert.ErrorContains(t, err, "number, printf, t")
+
+	for name, value := range map[string]any{
+		"notAFunc":   "value",
+		"nilFunc":    nil,
+		"tooMany":    func() (string, string, error) { return "", "", nil },
+		"bad-name":   strings.ToUpper,
+		"notAnError": func() (string, string) { return "", "" },
+	} {
+		assert.Error(t, validateFuncs(template.FuncMap{name: value}), name)
+	}
+}
@@ -0,0 +1,371 @@
+package discordgoi18n
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	gettextContextSeparator = "\x04"
+	gettextPluralSeparator  = "\x00"
+	gettextPluralFormsField = "Plural-Forms"
+	// defaultPluralForms is used by files without Plural-Forms header, as gettext does.
+	defaultPluralForms = "nplurals=2; plural=(n != 1);"
+
+	moMagic        = 0x950412de
+	moSwappedMagic = 0xde120495
+	moHeaderSize   = 28
+	moEntrySize    = 8
+)
+
+// gettextEntry is a message of a gettext catalog; translations holds msgstr or every msgstr[n].
+type gettextEntry struct {
+	context      string
+	id           string
+	plural       string
+	translations []string
+	fuzzy        bool
+}
+
+func (translator *translatorImpl) LoadGettext(locale discordgo.Locale, path string, fuzzy bool) error {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	return translator.loadGettextBuf(locale, path, buf, translator.buildCachePath(path, osSource), fuzzy)
+}
+
+func (translator *translatorImpl) LoadGettextFS(locale discordgo.Locale, fsys fs.FS, path string, fuzzy bool) error {
+	buf, err := fs.ReadFile(fsys, path)
+	if err != nil {
+		return err
+	}
+
+	return translator.loadGettextBuf(locale, path, buf, translator.buildCachePath(path, fsSource), fuzzy)
+}
+
+// loadGettextBuf converts the PO or MO file into bundle content, then compiles and stores it
+// like the content given to LoadBundleContent.
+func (translator *translatorImpl) loadGettextBuf(locale discordgo.Locale, file string, buf []byte, cachePath string,
+	fuzzy bool) error {
+	content, err := decodeGettext(locale, buf, fuzzy)
+	if err != nil {
+		return fmt.Errorf("cannot decode gettext file '%s': %w", file, err)
+	}
+
+	layer, err := translator.newLayer(translator.state.Load(), locale, cachePath, content)
+	if err != nil {
+		return fmt.Errorf("cannot compile gettext file '%s': %w", file, err)
+	}
+
+	translator.logger.Debug().Msgf("Bundle '%s' loaded with '%s' content", locale, cachePath)
+	return translator.storeBundle(locale, layer)
+}
+
+// decodeGettext converts a PO or MO file into bundle content: msgctxt and msgid are joined
+// by keyDelim into keys and msgstr[n] become the plural forms of locale, matched through the
+// Plural-Forms header. Untranslated messages are skipped, as well as fuzzy ones unless fuzzy
+// is set.
+func decodeGettext(locale discordgo.Locale, buf []byte, fuzzy bool) (map[string]any, error) {
+	parse := parsePO
+	if isMO(buf) {
+		parse = parseMO
+	}
+
+	entries, err := parse(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	pluralForms := defaultPluralForms
+	for _, entry := range entries {
+		if entry.context == "" && entry.id == "" && len(entry.translations) > 0 {
+			if field, found := headerField(entry.translations[0], gettextPluralFormsField); found {
+				pluralForms = field
+			}
+		}
+	}
+
+	count, formula, err := parsePluralForms(pluralForms)
+	if err != nil {
+		return nil, err
+	}
+
+	if parsed, found := parseLocale(string(locale)); found {
+		locale = parsed
+	}
+
+	indexes, err := pluralFormIndexes(locale, count, formula)
+	if err != nil {
+		return nil, err
+	}
+
+	content := make(map[string]any, len(entries))
+	for _, entry := range entries {
+		if entry.id == "" || entry.fuzzy && !fuzzy {
+			continue
+		}
+
+		key := entry.id
+		if entry.context != "" {
+			key = entry.context + keyDelim + entry.id
+		}
+
+		if value, translated := entry.value(indexes); translated {
+			content[key] = value
+		}
+	}
+
+	return content, nil
+}
+
+// value returns the msgstr of the entry, or its plural forms selected by indexes; false is
+// returned when the entry is not translated.
+func (entry gettextEntry) value(indexes map[pluralCategory]int) (any, bool) {
+	if entry.plural == "" {
+		if len(entry.translations) == 0 || entry.translations[0] == "" {
+			return nil, false
+		}
+		return entry.translations[0], true
+	}
+
+	forms := make(map[string]any, len(indexes)+1)
+	forms[pluralKey] = pluralTypeCardinal
+	for category, index := range indexes {
+		if index >= len(entry.translations) || entry.translations[index] == "" {
+			return nil, false
+		}
+		forms[string(category)] = entry.translations[index]
+	}
+
+	return forms, true
+}
+
+// pluralFormIndexes maps the cardinal categories of locale to the gettext plural form index
+// formula computes for their samples. The last form is used for the other category when
+// integers never reach it, such as in Russian.
+func pluralFormIndexes(locale discordgo.Locale, count int, formula pluralFormula) (map[pluralCategory]int, error) {
+	rule := cardinalRule(locale)
+	indexes := make(map[pluralCategory]int)
+	for _, n := range pluralSampleRange() {
+		operands, err := newPluralOperands(n)
+		if err != nil {
+			return nil, err
+		}
+
+		category := rule(operands)
+		if _, found := indexes[category]; found {
+			continue
+		}
+
+		index := formula(n)
+		if index < 0 || index >= int64(count) {
+			return nil, fmt.Errorf("plural form %d of %d is out of the %d plural forms", index, n, count)
+		}
+		indexes[category] = int(index)
+	}
+
+	if _, found := indexes[pluralOther]; !found {
+		indexes[pluralOther] = count - 1
+	}
+
+	return indexes, nil
+}
+
+// headerField returns the value of the field name in the header of a gettext catalog.
+func headerField(header, name string) (string, bool) {
+	for _, line := range strings.Split(header, "\n") {
+		field, value, found := strings.Cut(line, ":")
+		if found && strings.EqualFold(strings.TrimSpace(field), name) {
+			return strings.TrimSpace(value), true
+		}
+	}
+
+	return "", false
+}
+
+// poParser reads PO files line by line, entries being completed once a msgstr is read.
+type poParser struct {
+	entries  []gettextEntry
+	current  gettextEntry
+	complete bool
+	// appendTo receives the continuation strings of the last keyword read.
+	appendTo func(value string)
+}
+
+// parsePO parses the entries of a PO file, obsolete ones excluded.
+func parsePO(buf []byte) ([]gettextEntry, error) {
+	parser := &poParser{}
+	for i, line := range strings.Split(string(buf), "\n") {
+		if err := parser.parseLine(strings.TrimSpace(line)); err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+	}
+	parser.next()
+
+	return parser.entries, nil
+}
+
+func (parser *poParser) parseLine(line string) error {
+	switch {
+	case line == "":
+		return nil
+	case strings.HasPrefix(line, "#"):
+		parser.next()
+		if flags, isFlags := strings.CutPrefix(line, "#,"); isFlags {
+			for _, flag := range strings.Split(flags, ",") {
+				parser.current.fuzzy = parser.current.fuzzy || strings.TrimSpace(flag) == "fuzzy"
+			}
+		}
+		return nil
+	case strings.HasPrefix(line, `"`):
+		if parser.appendTo == nil {
+			return errors.New("string without keyword")
+		}
+		value, err := unquotePO(line)
+		if err != nil {
+			return err
+		}
+		parser.appendTo(value)
+		return nil
+	}
+
+	keyword, quoted, _ := strings.Cut(line, " ")
+	value, err := unquotePO(strings.TrimSpace(quoted))
+	if err != nil {
+		return err
+	}
+
+	entry := &parser.current
+	switch keyword {
+	case "msgctxt":
+		parser.next()
+		entry.context = value
+		parser.appendTo = func(value string) { entry.context += value }
+	case "msgid":
+		parser.next()
+		entry.id = value
+		parser.appendTo = func(value string) { entry.id += value }
+	case "msgid_plural":
+		entry.plural = value
+		parser.appendTo = func(value string) { entry.plural += value }
+	default:
+		return parser.parseMsgstr(keyword, value)
+	}
+
+	return nil
+}
+
+// parseMsgstr reads msgstr or msgstr[n] keywords.
+func (parser *poParser) parseMsgstr(keyword, value string) error {
+	index := 0
+	if keyword != "msgstr" {
+		rawIndex, isMsgstr := strings.CutPrefix(keyword, "msgstr[")
+		rawIndex, isIndexed := strings.CutSuffix(rawIndex, "]")
+		var err error
+		if index, err = strconv.Atoi(rawIndex); !isMsgstr || !isIndexed || err != nil || index < 0 {
+			return fmt.Errorf("unexpected keyword '%s'", keyword)
+		}
+	}
+
+	entry := &parser.current
+	for len(entry.translations) <= index {
+		entry.translations = append(entry.translations, "")
+	}
+	entry.translations[index] = value
+	parser.appendTo = func(value string) { entry.translations[index] += value }
+	parser.complete = true
+
+	return nil
+}
+
+// next stores the current entry once complete, keywords and comments read afterwards
+// belonging to the next one.
+func (parser *poParser) next() {
+	if parser.complete {
+		parser.entries = append(parser.entries, parser.current)
+		parser.current = gettextEntry{}
+		parser.complete = false
+	}
+	parser.appendTo = nil
+}
+
+func unquotePO(quoted string) (string, error) {
+	value, err := strconv.Unquote(quoted)
+	if err != nil || !strings.HasPrefix(quoted, `"`) {
+		return "", fmt.Errorf("invalid string %s", quoted)
+	}
+
+	return value, nil
+}
+
+func isMO(buf []byte) bool {
+	if len(buf) < 4 {
+		return false
+	}
+
+	magic := binary.LittleEndian.Uint32(buf)
+	return magic == moMagic || magic == moSwappedMagic
+}
+
+// parseMO parses the entries of a MO file, written in either byte order.
+func parseMO(buf []byte) ([]gettextEntry, error) {
+	if len(buf) < moHeaderSize {
+		return nil, errors.New("truncated MO header")
+	}
+
+	var order binary.ByteOrder = binary.LittleEndian
+	if binary.LittleEndian.Uint32(buf) == moSwappedMagic {
+		order = binary.BigEndian
+	}
+
+	count := uint64(order.Uint32(buf[8:]))
+	originals := uint64(order.Uint32(buf[12:]))
+	translations := uint64(order.Uint32(buf[16:]))
+	size := uint64(len(buf))
+
+	readString := func(table, i uint64) (string, error) {
+		descriptor := table + i*moEntrySize
+		if descriptor+moEntrySize > size {
+			return "", fmt.Errorf("truncated MO string table at entry %d", i)
+		}
+
+		length := uint64(order.Uint32(buf[descriptor:]))
+		offset := uint64(order.Uint32(buf[descriptor+4:]))
+		if offset+length > size {
+			return "", fmt.Errorf("truncated MO string at entry %d", i)
+		}
+		return string(buf[offset : offset+length]), nil
+	}
+
+	entries := make([]gettextEntry, 0, min(count, size/moEntrySize))
+	for i := range count {
+		original, err := readString(originals, i)
+		if err != nil {
+			return nil, err
+		}
+
+		translation, err := readString(translations, i)
+		if err != nil {
+			return nil, err
+		}
+
+		var entry gettextEntry
+		if context, id, found := strings.Cut(original, gettextContextSeparator); found {
+			entry.context, original = context, id
+		}
+		entry.id, entry.plural, _ = strings.Cut(original, gettextPluralSeparator)
+		entry.translations = strings.Split(translation, gettextPluralSeparator)
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
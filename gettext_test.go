@@ -0,0 +1,169 @@
+package discordgoi18n
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+)
+
+const gettextRussianPO = `# Russian translations
+msgid ""
+msgstr ""
+"Content-Type: text/plain; charset=UTF-8\n"
+"Plural-Forms: nplurals=3; plural=(n%10==1 && n%100!=11 ? 0 : "
+"n%10>=2 && n%10<=4 && (n%100<10 || n%100>=20) ? 1 : 2);\n"
+
+#: commands/ping.go:12
+msgid "hello"
+msgstr "Привет {{ .name }}"
+
+msgctxt "command.ping"
+msgid "description"
+msgstr ""
+"Отвечает "
+"\"понг\"\n"
+
+#, fuzzy, go-format
+msgid "draft"
+msgstr "Черновик"
+
+msgid "untranslated"
+msgstr ""
+
+msgid "coin"
+msgid_plural "coins"
+msgstr[0] "{{ .count }} монета"
+msgstr[1] "{{ .count }} монеты"
+msgstr[2] "{{ .count }} монет"
+
+msgid "partial"
+msgid_plural "partials"
+msgstr[0] "{{ .count }} часть"
+msgstr[1] ""
+msgstr[2] "{{ .count }} частей"
+
+#~ msgid "obsolete"
+#~ msgstr "Устаревший"
+`
+
+// Test decoding PO files with contexts, plural forms and fuzzy entries
+func TestDecodeGettextPO(t *testing.T) {
+	expected := map[string]any{
+		"hello":                    "Привет {{ .name }}",
+		"command.ping.description": "Отвечает \"понг\"\n",
+		"coin": map[string]any{
+			"$plural": "cardinal",
+			"one":     "{{ .count }} монета",
+			"few":     "{{ .count }} монеты",
+			"many":    "{{ .count }} монет",
+			// Russian integers are never other, the last form is used for decimals
+			"other": "{{ .count }} монет",
+		},
+	}
+
+	content, err := decodeGettext(discordgo.Russian, []byte(gettextRussianPO), false)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, content)
+
+	content, err = decodeGettext(discordgo.Russian, []byte(gettextRussianPO), true)
+	assert.NoError(t, err)
+	assert.Equal(t, "Черновик", content["draft"])
+
+	// Without Plural-Forms header, the form of 1 is singular and the other one plural
+	content, err = decodeGettext(discordgo.French, []byte(`
+msgid "coin"
+msgid_plural "coins"
+msgstr[0] "{{ .count }} pièce"
+msgstr[1] "{{ .count }} pièces"
+`), false)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"coin": map[string]any{
+			"$plural": "cardinal",
+			"one":     "{{ .count }} pièce",
+			"many":    "{{ .count }} pièces",
+			"other":   "{{ .count }} pièces",
+		},
+	}, content)
+}
+
+// Test rejecting invalid gettext files
+func TestDecodeGettextErrors(t *testing.T) {
+	for po, expected := range map[string]string{
+		"msgid \"hello\"\nmsgstr \"unterminated\n":                     "line 2: invalid string",
+		"msgid \"hello\"\nmsgtxt \"Bonjour\"\n":                        "line 2: unexpected keyword 'msgtxt'",
+		"msgid \"hello\"\nmsgstr[x] \"Bonjour\"\n":                     "line 2: unexpected keyword 'msgstr[x]'",
+		"# comment\n\"orphan\"\n":                                      "line 2: string without keyword",
+		"msgid \"\"\nmsgstr \"Plural-Forms: nplurals=2;\"\n":           "plural",
+		"msgid \"\"\nmsgstr \"Plural-Forms: nplurals=2; plural=n;\"\n": "plural form 2 of 2 is out of the 2 plural forms",
+	} {
+		_, err := decodeGettext(discordgo.French, []byte(po), false)
+		assert.ErrorContains(t, err, expected, po)
+	}
+
+	_, err := decodeGettext(discordgo.French, buildMO(binary.LittleEndian, map[string]string{"hello": "Bonjour"})[:40], false)
+	assert.ErrorContains(t, err, "truncated MO")
+
+	_, err = decodeGettext(discordgo.French, []byte{0xde, 0x12, 0x04, 0x95}, false)
+	assert.ErrorContains(t, err, "truncated MO header")
+}
+
+// Test decoding MO files written in both byte orders
+func TestDecodeGettextMO(t *testing.T) {
+	messages := map[string]string{
+		"":                            "Plural-Forms: nplurals=2; plural=(n > 1);\n",
+		"hello":                       "Bonjour {{ .name }}",
+		"command.ping\x04description": "Répond pong",
+		"coin\x00coins":               "{{ .count }} pièce\x00{{ .count }} pièces",
+		"untranslated":                "",
+	}
+
+	for _, order := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian} {
+		content, err := decodeGettext(discordgo.French, buildMO(order, messages), false)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]any{
+			"hello":                    "Bonjour {{ .name }}",
+			"command.ping.description": "Répond pong",
+			"coin": map[string]any{
+				"$plural": "cardinal",
+				"one":     "{{ .count }} pièce",
+				"many":    "{{ .count }} pièces",
+				"other":   "{{ .count }} pièces",
+			},
+		}, content)
+	}
+}
+
+// buildMO encodes messages as a MO file, keys being originals and values translations.
+func buildMO(order binary.ByteOrder, messages map[string]string) []byte {
+	originals := make([]string, 0, len(messages))
+	for original := range messages {
+		originals = append(originals, original)
+	}
+
+	count := uint32(len(originals))
+	originalsTable := uint32(moHeaderSize)
+	translationsTable := originalsTable + count*moEntrySize
+	offset := translationsTable + count*moEntrySize
+
+	buf := make([]byte, offset)
+	order.PutUint32(buf, moMagic)
+	order.PutUint32(buf[8:], count)
+	order.PutUint32(buf[12:], originalsTable)
+	order.PutUint32(buf[16:], translationsTable)
+
+	write := func(descriptor uint32, value string) {
+		order.PutUint32(buf[descriptor:], uint32(len(value)))
+		order.PutUint32(buf[descriptor+4:], uint32(len(buf)))
+		buf = append(buf, value...)
+		buf = append(buf, 0)
+	}
+	for i, original := range originals {
+		write(originalsTable+uint32(i)*moEntrySize, original)
+		write(translationsTable+uint32(i)*moEntrySize, messages[original])
+	}
+
+	return buf
+}
@@ -0,0 +1,589 @@
+package discordgoi18n
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	icuLeftBrace  = '{'
+	icuRightBrace = '}'
+	icuQuote      = '\''
+	icuPound      = '#'
+	icuSeparator  = ','
+	icuExactMatch = '='
+	icuOffset     = "offset:"
+
+	icuTypeNumber        = "number"
+	icuStyleInteger      = "integer"
+	icuStylePercent      = "percent"
+	icuStyleCurrency     = "::currency/"
+	icuTypeDate          = "date"
+	icuTypeTime          = "time"
+	icuTypePlural        = "plural"
+	icuTypeSelectOrdinal = "selectordinal"
+	icuTypeSelect        = "select"
+	icuSelectOther       = "other"
+)
+
+// icuMessage is a parsed ICU MessageFormat message, see
+// https://unicode-org.github.io/icu/userguide/format_parse/messages/.
+type icuMessage []icuNode
+
+// icuNode is a part of an ICU message.
+type icuNode interface {
+	format(buf *strings.Builder, context *icuContext) error
+}
+
+// icuContext is what an ICU message needs to be formatted: values are formatted with the
+// rendering locale while plural arguments rely on the rules of pluralLocale. pound is
+// the number formatted by # inside the closest plural argument.
+type icuContext struct {
+	locale       discordgo.Locale
+	pluralLocale discordgo.Locale
+	format       numberFormat
+	variables    Vars
+	pound        *float64
+	sanitizer    sanitizer
+}
+
+type icuText string
+
+type icuPoundNode struct{}
+
+type icuArgument struct {
+	name  string
+	kind  string
+	style string
+}
+
+type icuPlural struct {
+	name    string
+	offset  float64
+	ordinal bool
+	exact   map[string]icuMessage
+	forms   map[pluralCategory]icuMessage
+}
+
+type icuSelect struct {
+	name  string
+	cases map[string]icuMessage
+}
+
+// icuParser is a recursive descent parser of ICU messages, quoting follows the ICU
+// DOUBLE_OPTIONAL apostrophe mode.
+type icuParser struct {
+	raw      []rune
+	position int
+}
+
+// parseICUMessage parses raw as an ICU message.
+func parseICUMessage(raw string) (icuMessage, error) {
+	parser := &icuParser{raw: []rune(raw)}
+	message, err := parser.parseMessage(false, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if !parser.done() {
+		return nil, parser.errorf("unexpected '%c'", parser.current())
+	}
+
+	return message, nil
+}
+
+func (parser *icuParser) done() bool {
+	return parser.position >= len(parser.raw)
+}
+
+func (parser *icuParser) current() rune {
+	return parser.raw[parser.position]
+}
+
+func (parser *icuParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("invalid ICU message at position %d: %s", parser.position, fmt.Sprintf(format, args...))
+}
+
+// parseMessage parses text and arguments until the end of raw, or until the closing
+// brace of a sub-message which is left unconsumed.
+func (parser *icuParser) parseMessage(nested, inPlural bool) (icuMessage, error) {
+	var message icuMessage
+	var text strings.Builder
+	flushText := func() {
+		if text.Len() > 0 {
+			message = append(message, icuText(text.String()))
+			text.Reset()
+		}
+	}
+
+	for !parser.done() {
+		char := parser.current()
+		switch {
+		case char == icuQuote:
+			parser.parseQuote(&text, inPlural)
+		case char == icuLeftBrace:
+			flushText()
+			node, err := parser.parseArgument()
+			if err != nil {
+				return nil, err
+			}
+			message = append(message, node)
+		case char == icuRightBrace:
+			if !nested {
+				return nil, parser.errorf("unexpected '%c'", char)
+			}
+			flushText()
+			return message, nil
+		case char == icuPound && inPlural:
+			flushText()
+			message = append(message, icuPoundNode{})
+			parser.position++
+		default:
+			text.WriteRune(char)
+			parser.position++
+		}
+	}
+
+	if nested {
+		return nil, parser.errorf("unclosed sub-message")
+	}
+
+	flushText()
+	return message, nil
+}
+
+// parseQuote handles apostrophes: a doubled apostrophe is a literal one, an apostrophe before a syntax
+// character starts a quoted literal text, any other apostrophe is kept as is.
+func (parser *icuParser) parseQuote(text *strings.Builder, inPlural bool) {
+	parser.position++
+	if parser.done() {
+		text.WriteRune(icuQuote)
+		return
+	}
+
+	switch next := parser.current(); {
+	case next == icuQuote:
+		text.WriteRune(icuQuote)
+		parser.position++
+	case next == icuLeftBrace, next == icuRightBrace, next == icuPound && inPlural:
+		for !parser.done() {
+			char := parser.current()
+			parser.position++
+			if char != icuQuote {
+				text.WriteRune(char)
+				continue
+			}
+
+			if parser.done() || parser.current() != icuQuote {
+				return
+			}
+			text.WriteRune(icuQuote)
+			parser.position++
+		}
+	default:
+		text.WriteRune(icuQuote)
+	}
+}
+
+func (parser *icuParser) skipSpaces() {
+	for !parser.done() && unicode.IsSpace(parser.current()) {
+		parser.position++
+	}
+}
+
+// parseWord reads characters until a space or a syntax character.
+func (parser *icuParser) parseWord() string {
+	start := parser.position
+	for !parser.done() {
+		char := parser.current()
+		if unicode.IsSpace(char) || char == icuSeparator || char == icuLeftBrace || char == icuRightBrace {
+			break
+		}
+		parser.position++
+	}
+
+	return string(parser.raw[start:parser.position])
+}
+
+func (parser *icuParser) expect(char rune) error {
+	parser.skipSpaces()
+	if parser.done() || parser.current() != char {
+		return parser.errorf("'%c' expected", char)
+	}
+
+	parser.position++
+	return nil
+}
+
+// parseArgument parses {name}, {name, type}, {name, type, style} and complex arguments.
+func (parser *icuParser) parseArgument() (icuNode, error) {
+	parser.position++ // Opening brace
+	parser.skipSpaces()
+	name := parser.parseWord()
+	if name == "" {
+		return nil, parser.errorf("argument name expected")
+	}
+
+	parser.skipSpaces()
+	if !parser.done() && parser.current() == icuRightBrace {
+		parser.position++
+		return icuArgument{name: name}, nil
+	}
+
+	if err := parser.expect(icuSeparator); err != nil {
+		return nil, err
+	}
+
+	parser.skipSpaces()
+	kind := parser.parseWord()
+	switch kind {
+	case icuTypePlural, icuTypeSelectOrdinal:
+		return parser.parsePlural(name, kind == icuTypeSelectOrdinal)
+	case icuTypeSelect:
+		return parser.parseSelect(name)
+	case icuTypeNumber, icuTypeDate, icuTypeTime:
+		return parser.parseSimpleArgument(name, kind)
+	default:
+		return nil, parser.errorf("unsupported argument type '%s'", kind)
+	}
+}
+
+// parseSimpleArgument parses the optional style of an argument until its closing brace.
+func (parser *icuParser) parseSimpleArgument(name, kind string) (icuNode, error) {
+	parser.skipSpaces()
+	if !parser.done() && parser.current() == icuRightBrace {
+		parser.position++
+		return icuArgument{name: name, kind: kind}, nil
+	}
+
+	if err := parser.expect(icuSeparator); err != nil {
+		return nil, err
+	}
+
+	start := parser.position
+	for !parser.done() && parser.current() != icuRightBrace {
+		parser.position++
+	}
+
+	style := strings.TrimSpace(string(parser.raw[start:parser.position]))
+	if err := parser.expect(icuRightBrace); err != nil {
+		return nil, err
+	}
+
+	if !isSupportedStyle(kind, style) {
+		return nil, parser.errorf("unsupported %s style '%s'", kind, style)
+	}
+
+	return icuArgument{name: name, kind: kind, style: style}, nil
+}
+
+// isSupportedStyle reports whether style can be used with an argument of type kind.
+func isSupportedStyle(kind, style string) bool {
+	if kind == icuTypeNumber {
+		return style == icuStyleInteger || style == icuStylePercent || strings.HasPrefix(style, icuStyleCurrency)
+	}
+
+	return style == styleShort || style == styleMedium || style == styleLong
+}
+
+func (parser *icuParser) parsePlural(name string, ordinal bool) (icuNode, error) {
+	if err := parser.expect(icuSeparator); err != nil {
+		return nil, err
+	}
+
+	plural := icuPlural{
+		name:    name,
+		ordinal: ordinal,
+		exact:   make(map[string]icuMessage),
+		forms:   make(map[pluralCategory]icuMessage),
+	}
+
+	parser.skipSpaces()
+	if strings.HasPrefix(string(parser.raw[parser.position:]), icuOffset) {
+		parser.position += len(icuOffset)
+		parser.skipSpaces()
+		offset, err := strconv.ParseFloat(parser.parseWord(), 64)
+		if err != nil {
+			return nil, parser.errorf("invalid plural offset: %v", err)
+		}
+		plural.offset = offset
+	}
+
+	err := parser.parseCases(func(selector string, message icuMessage) error {
+		if strings.HasPrefix(selector, string(icuExactMatch)) {
+			if _, err := strconv.ParseFloat(selector[1:], 64); err != nil {
+				return parser.errorf("invalid plural selector '%s'", selector)
+			}
+			plural.exact[selector[1:]] = message
+			return nil
+		}
+
+		switch category := pluralCategory(selector); category {
+		case pluralZero, pluralOne, pluralTwo, pluralFew, pluralMany, pluralOther:
+			plural.forms[category] = message
+			return nil
+		default:
+			return parser.errorf("invalid plural selector '%s'", selector)
+		}
+	}, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, found := plural.forms[pluralOther]; !found {
+		return nil, parser.errorf("'%s' plural argument does not define the other case", name)
+	}
+
+	return plural, nil
+}
+
+func (parser *icuParser) parseSelect(name string) (icuNode, error) {
+	if err := parser.expect(icuSeparator); err != nil {
+		return nil, err
+	}
+
+	selectNode := icuSelect{name: name, cases: make(map[string]icuMessage)}
+	err := parser.parseCases(func(selector string, message icuMessage) error {
+		selectNode.cases[selector] = message
+		return nil
+	}, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, found := selectNode.cases[icuSelectOther]; !found {
+		return nil, parser.errorf("'%s' select argument does not define the other case", name)
+	}
+
+	return selectNode, nil
+}
+
+// parseCases parses "selector {message}" pairs until the closing brace of the argument.
+func (parser *icuParser) parseCases(addCase func(selector string, message icuMessage) error, inPlural bool) error {
+	for {
+		parser.skipSpaces()
+		if parser.done() {
+			return parser.errorf("unclosed argument")
+		}
+
+		if parser.current() == icuRightBrace {
+			parser.position++
+			return nil
+		}
+
+		selector := parser.parseWord()
+		if selector == "" {
+			return parser.errorf("selector expected")
+		}
+
+		if err := parser.expect(icuLeftBrace); err != nil {
+			return err
+		}
+
+		message, err := parser.parseMessage(true, inPlural)
+		if err != nil {
+			return err
+		}
+		parser.position++ // Closing brace of the sub-message
+
+		if err = addCase(selector, message); err != nil {
+			return err
+		}
+	}
+}
+
+func (message icuMessage) hasArguments() bool {
+	for _, node := range message {
+		if _, isText := node.(icuText); !isText {
+			return true
+		}
+	}
+
+	return false
+}
+
+// format renders the message with the given variables.
+func (message icuMessage) format(buf *strings.Builder, context *icuContext) error {
+	for _, node := range message {
+		if err := node.format(buf, context); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (text icuText) format(buf *strings.Builder, _ *icuContext) error {
+	buf.WriteString(string(text))
+	return nil
+}
+
+func (icuPoundNode) format(buf *strings.Builder, context *icuContext) error {
+	if context.pound == nil {
+		buf.WriteRune(icuPound)
+		return nil
+	}
+
+	formatted, err := context.format.formatNumber(*context.pound, 0, defaultFractionDigits)
+	if err != nil {
+		return err
+	}
+
+	buf.WriteString(formatted)
+	return nil
+}
+
+func (argument icuArgument) format(buf *strings.Builder, context *icuContext) error {
+	value, err := context.variable(argument.name)
+	if err != nil {
+		return err
+	}
+
+	switch argument.kind {
+	case icuTypeNumber:
+		value, err = argument.formatNumber(value, context.format)
+	case icuTypeDate, icuTypeTime:
+		value, err = argument.formatDateTime(value, context.locale)
+	default:
+		if context.sanitizer.enabled() {
+			value = context.sanitizer.sanitize(value)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	buf.WriteString(fmt.Sprint(value))
+	return nil
+}
+
+func (argument icuArgument) formatNumber(value any, format numberFormat) (string, error) {
+	switch {
+	case argument.style == icuStyleInteger:
+		return format.formatNumber(value, 0, 0)
+	case argument.style == icuStylePercent:
+		return format.formatPercent(value, percentFractionDigits)
+	case strings.HasPrefix(argument.style, icuStyleCurrency):
+		return format.formatCurrency(strings.TrimPrefix(argument.style, icuStyleCurrency), value)
+	default:
+		return format.formatNumber(value, 0, defaultFractionDigits)
+	}
+}
+
+func (argument icuArgument) formatDateTime(value any, locale discordgo.Locale) (string, error) {
+	style := argument.style
+	if style == "" {
+		style = styleMedium
+	}
+
+	format := newDateTimeFormat(locale)
+	if argument.kind == icuTypeDate {
+		return format.formatDate(value, style)
+	}
+
+	return format.formatTime(value, style)
+}
+
+func (plural icuPlural) format(buf *strings.Builder, context *icuContext) error {
+	value, err := context.variable(plural.name)
+	if err != nil {
+		return err
+	}
+
+	number, err := toFloat(value)
+	if err != nil {
+		return err
+	}
+
+	pound := number - plural.offset
+	subContext := &icuContext{
+		locale:       context.locale,
+		pluralLocale: context.pluralLocale,
+		format:       context.format,
+		variables:    context.variables,
+		pound:        &pound,
+		sanitizer:    context.sanitizer,
+	}
+
+	if message, found := plural.exact[strconv.FormatFloat(number, 'f', -1, 64)]; found {
+		return message.format(buf, subContext)
+	}
+
+	operands, err := newPluralOperands(pound)
+	if err != nil {
+		return err
+	}
+
+	rule := cardinalRule(context.pluralLocale)
+	if plural.ordinal {
+		rule = ordinalRule(context.pluralLocale)
+	}
+
+	message, found := plural.forms[rule(operands)]
+	if !found {
+		message = plural.forms[pluralOther]
+	}
+
+	return message.format(buf, subContext)
+}
+
+func (selectNode icuSelect) format(buf *strings.Builder, context *icuContext) error {
+	value, err := context.variable(selectNode.name)
+	if err != nil {
+		return err
+	}
+
+	message, found := selectNode.cases[fmt.Sprint(value)]
+	if !found {
+		message = selectNode.cases[icuSelectOther]
+	}
+
+	return message.format(buf, context)
+}
+
+func (context *icuContext) variable(name string) (any, error) {
+	value, found := context.variables[name]
+	if !found {
+		return nil, fmt.Errorf("map has no entry for key %q", name)
+	}
+
+	return value, nil
+}
+
+// toFloat converts any integer, float or numeric string to float64.
+func toFloat(value any) (float64, error) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), nil
+	case int8:
+		return float64(v), nil
+	case int16:
+		return float64(v), nil
+	case int32:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case uint:
+		return float64(v), nil
+	case uint8:
+		return float64(v), nil
+	case uint16:
+		return float64(v), nil
+	case uint32:
+		return float64(v), nil
+	case uint64:
+		return float64(v), nil
+	case float32:
+		return float64(v), nil
+	case float64:
+		return v, nil
+	case string:
+		return strconv.ParseFloat(v, 64)
+	default:
+		return 0, fmt.Errorf("'%v' of type %T is not a number", value, value)
+	}
+}
@@ -0,0 +1,126 @@
+package discordgoi18n
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+)
+
+func formatICU(t *testing.T, locale discordgo.Locale, raw string, variables Vars) string {
+	t.Helper()
+	message, err := parseICUMessage(raw)
+	assert.NoError(t, err, raw)
+
+	var buf strings.Builder
+	context := &icuContext{locale: locale, pluralLocale: locale, format: newNumberFormat(locale), variables: variables}
+	assert.NoError(t, message.format(&buf, context), raw)
+	return buf.String()
+}
+
+// Test formatting ICU messages
+func TestICUMessageFormat(t *testing.T) {
+	items := "{count, plural, =0 {no item} one {# item} other {# items}}"
+	assert.Equal(t, "Hello world!", formatICU(t, defaultLocale, "Hello world!", nil))
+	assert.Equal(t, "Hello Nick!", formatICU(t, defaultLocale, "Hello {anyone}!", Vars{"anyone": "Nick"}))
+	assert.Equal(t, "Hello Nick!", formatICU(t, defaultLocale, "Hello { anyone }!", Vars{"anyone": "Nick"}))
+	assert.Equal(t, "1,234.5", formatICU(t, defaultLocale, "{n, number}", Vars{"n": 1234.5}))
+	assert.Equal(t, "1\u202f234,5", formatICU(t, discordgo.French, "{n, number}", Vars{"n": 1234.5}))
+	assert.Equal(t, "1.235", formatICU(t, discordgo.German, "{n, number, integer}", Vars{"n": 1234.7}))
+	assert.Equal(t, "25\u00a0%", formatICU(t, discordgo.German, "{n, number, percent}", Vars{"n": 0.25}))
+	assert.Equal(t, "1.234,50\u00a0€", formatICU(t, discordgo.German, "{n, number, ::currency/EUR}", Vars{"n": 1234.5}))
+	assert.Equal(t, "1,234 items", formatICU(t, defaultLocale, "{n, plural, one {# item} other {# items}}", Vars{"n": 1234}))
+
+	// Dates and times
+	date := time.Date(2024, time.March, 5, 14, 7, 9, 0, time.UTC)
+	assert.Equal(t, "Mar 5, 2024", formatICU(t, defaultLocale, "{d, date}", Vars{"d": date}))
+	assert.Equal(t, "5 mars 2024", formatICU(t, discordgo.French, "{d, date, long}", Vars{"d": date}))
+	assert.Equal(t, "05.03.24 um 14:07", formatICU(t, discordgo.German, "{d, date, short} um {d, time, short}", Vars{"d": date}))
+	assert.Equal(t, "2:07:09 PM", formatICU(t, defaultLocale, "{d, time}", Vars{"d": date.Unix()}))
+	assert.Equal(t, "Ends 5 de marzo de 2024", formatICU(t, discordgo.SpanishES,
+		"{n, plural, one {Ends {d, date, long}} other {# ends}}", Vars{"n": 1, "d": date}))
+
+	// Plural
+	assert.Equal(t, "no item", formatICU(t, defaultLocale, items, Vars{"count": 0}))
+	assert.Equal(t, "1 item", formatICU(t, defaultLocale, items, Vars{"count": 1}))
+	assert.Equal(t, "5 items", formatICU(t, defaultLocale, items, Vars{"count": 5}))
+	assert.Equal(t, "5 items", formatICU(t, defaultLocale, items, Vars{"count": "5"}))
+	assert.Equal(t, "22 przedmioty", formatICU(t, discordgo.Polish,
+		"{count, plural, one {# przedmiot} few {# przedmioty} many {# przedmiotów} other {# przedmiotu}}",
+		Vars{"count": 22}))
+
+	// Offset and exact matches
+	guests := "{guests, plural, offset:1 =0 {nobody} =1 {{host}} one {{host} and # other} other {{host} and # others}}"
+	assert.Equal(t, "nobody", formatICU(t, defaultLocale, guests, Vars{"guests": 0, "host": "Ann"}))
+	assert.Equal(t, "Ann", formatICU(t, defaultLocale, guests, Vars{"guests": 1, "host": "Ann"}))
+	assert.Equal(t, "Ann and 1 other", formatICU(t, defaultLocale, guests, Vars{"guests": 2, "host": "Ann"}))
+	assert.Equal(t, "Ann and 4 others", formatICU(t, defaultLocale, guests, Vars{"guests": 5, "host": "Ann"}))
+
+	// Ordinal
+	rank := "{rank, selectordinal, one {#st} two {#nd} few {#rd} other {#th}}"
+	assert.Equal(t, "1st", formatICU(t, defaultLocale, rank, Vars{"rank": 1}))
+	assert.Equal(t, "12th", formatICU(t, defaultLocale, rank, Vars{"rank": 12}))
+	assert.Equal(t, "23rd", formatICU(t, defaultLocale, rank, Vars{"rank": 23}))
+
+	// Select, nested plural
+	welcome := "{gender, select, female {Bienvenue {name}} male {Bienvenu {name}} other {Bienvenue à toi}}"
+	assert.Equal(t, "Bienvenue Ann", formatICU(t, discordgo.French, welcome, Vars{"gender": "female", "name": "Ann"}))
+	assert.Equal(t, "Bienvenu Bob", formatICU(t, discordgo.French, welcome, Vars{"gender": "male", "name": "Bob"}))
+	assert.Equal(t, "Bienvenue à toi", formatICU(t, discordgo.French, welcome, Vars{"gender": "unknown"}))
+	assert.Equal(t, "She has 2 cats", formatICU(t, defaultLocale,
+		"{g, select, female {She has {n, plural, one {# cat} other {# cats}}} other {They have # cats}}",
+		Vars{"g": "female", "n": 2}))
+
+	// Quoting: # outside of plurals is a literal text
+	assert.Equal(t, "It's {quoted} # ok", formatICU(t, defaultLocale, "It's '{quoted}' # ok", nil))
+	assert.Equal(t, "It's 'fine'", formatICU(t, defaultLocale, "It''s 'fine'", nil))
+	assert.Equal(t, "#1 and 2 items", formatICU(t, defaultLocale,
+		"{n, plural, other {'#'1 and # items}}", Vars{"n": 2}))
+}
+
+// Test ICU syntax errors
+func TestParseICUMessageErrors(t *testing.T) {
+	for _, raw := range []string{
+		"{",
+		"}",
+		"{}",
+		"{name",
+		"{name, duration}",
+		"{name, date, full}",
+		"{name, time, integer}",
+		"{name, number, integer",
+		"{name, number, scientific}",
+		"{count, plural, one {# item}}",
+		"{count, plural, one {# item} other {# items}",
+		"{count, plural, some {# item} other {# items}}",
+		"{count, plural, =x {# item} other {# items}}",
+		"{count, plural, offset:x other {# items}}",
+		"{count, plural other {# items}}",
+		"{gender, select, female {she}}",
+		"{gender, select, female she other {they}}",
+		"{gender, select, female {she} other {they}",
+	} {
+		_, err := parseICUMessage(raw)
+		assert.Error(t, err, raw)
+	}
+}
+
+// Test ICU runtime errors
+func TestICUMessageFormatErrors(t *testing.T) {
+	for raw, variables := range map[string]Vars{
+		"Hello {anyone}":                           {},
+		"{n, number}":                              {"n": "abc"},
+		"{n, plural, other {#}}":                   {"n": true},
+		"{m, plural, other {#}}":                   nil,
+		"{g, select, other {{name}}}":              {"g": "x"},
+		"{n, plural, one {{name}} other {others}}": {"n": 1},
+		"{d, date}":                                {"d": "today"},
+	} {
+		message, err := parseICUMessage(raw)
+		assert.NoError(t, err, raw)
+		context := &icuContext{locale: defaultLocale, pluralLocale: defaultLocale, format: newNumberFormat(defaultLocale), variables: variables}
+		assert.Error(t, message.format(&strings.Builder{}, context), raw)
+	}
+}
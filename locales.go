@@ -0,0 +1,97 @@
+package discordgoi18n
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// localeAliases returns the Discord locales of common language tags Discord does not use,
+// regional variants of single-region languages being handled by parseLocale.
+func localeAliases() map[string]discordgo.Locale {
+	return map[string]discordgo.Locale{
+		"en":      discordgo.EnglishUS,
+		"en-uk":   discordgo.EnglishGB,
+		"es":      discordgo.SpanishES,
+		"pt":      discordgo.PortugueseBR,
+		"pt-pt":   discordgo.PortugueseBR,
+		"sv":      discordgo.Swedish,
+		"zh":      discordgo.ChineseCN,
+		"zh-hans": discordgo.ChineseCN,
+		"zh-sg":   discordgo.ChineseCN,
+		"zh-hant": discordgo.ChineseTW,
+		"zh-hk":   discordgo.ChineseTW,
+		"nb":      discordgo.Norwegian,
+		"nn":      discordgo.Norwegian,
+		"nb-no":   discordgo.Norwegian,
+		"nn-no":   discordgo.Norwegian,
+	}
+}
+
+// parseLocale returns the Discord locale named by name, ignoring case and accepting
+// underscores as separator, such as pt_br. Common mistakes are normalized: aliases such as
+// en or pt, regional variants of languages Discord does not split such as fr-FR, English
+// variants as en-US and Latin American Spanish variants such as es-MX as es-419.
+func parseLocale(name string) (discordgo.Locale, bool) {
+	name = strings.ToLower(strings.ReplaceAll(name, "_", "-"))
+	for locale := range discordgo.Locales {
+		if locale != discordgo.Unknown && strings.EqualFold(string(locale), name) {
+			return locale, true
+		}
+	}
+
+	if locale, found := localeAliases()[name]; found {
+		return locale, true
+	}
+
+	language, region, found := strings.Cut(name, "-")
+	if !found || region == "" {
+		return discordgo.Unknown, false
+	}
+
+	switch language {
+	case "en":
+		return discordgo.EnglishUS, true
+	case "es":
+		return discordgo.SpanishLATAM, true
+	case "pt":
+		return discordgo.PortugueseBR, true
+	case "sv":
+		return discordgo.Swedish, true
+	case "zh":
+		return discordgo.Unknown, false
+	}
+
+	if _, found = discordgo.Locales[discordgo.Locale(language)]; found && language != "" {
+		return discordgo.Locale(language), true
+	}
+
+	return discordgo.Unknown, false
+}
+
+// validateLocale returns the Discord locale bundles of locale are stored for, normalizing
+// common mistakes. Locales Discord does not support are rejected in strict mode, kept with a
+// warning in lenient mode.
+func (translator *translatorImpl) validateLocale(locale discordgo.Locale) (discordgo.Locale, error) {
+	normalized, found := parseLocale(string(locale))
+	if found {
+		if normalized != locale {
+			translator.logger.Warn().Msgf("Locale '%s' is not a Discord locale, normalized to '%s'",
+				string(locale), string(normalized))
+		}
+		return normalized, nil
+	}
+
+	validation := translator.state.Load().localeValidation
+	switch validation {
+	case LocaleValidationStrict:
+		return "", fmt.Errorf("locale '%s' is not supported by Discord", string(locale))
+	case LocaleValidationLenient:
+		translator.logger.Warn().Msgf("Locale '%s' is not supported by Discord, its bundles cannot be reached "+
+			"by Discord users", string(locale))
+		return locale, nil
+	default:
+		return "", fmt.Errorf("unknown locale validation '%s'", validation)
+	}
+}
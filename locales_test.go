@@ -0,0 +1,52 @@
+package discordgoi18n
+
+import (
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test parsing and normalizing locale names
+func TestParseLocale(t *testing.T) {
+	for locale := range discordgo.Locales {
+		if locale == discordgo.Unknown {
+			continue
+		}
+
+		parsed, found := parseLocale(string(locale))
+		assert.True(t, found, locale)
+		assert.Equal(t, locale, parsed)
+	}
+
+	for name, expected := range map[string]discordgo.Locale{
+		"FR":      discordgo.French,
+		"fr-FR":   discordgo.French,
+		"fr_CA":   discordgo.French,
+		"de-AT":   discordgo.German,
+		"en":      discordgo.EnglishUS,
+		"en-AU":   discordgo.EnglishUS,
+		"en-UK":   discordgo.EnglishGB,
+		"en_gb":   discordgo.EnglishGB,
+		"es":      discordgo.SpanishES,
+		"es-MX":   discordgo.SpanishLATAM,
+		"pt":      discordgo.PortugueseBR,
+		"pt-PT":   discordgo.PortugueseBR,
+		"sv":      discordgo.Swedish,
+		"sv-FI":   discordgo.Swedish,
+		"nb":      discordgo.Norwegian,
+		"no-NO":   discordgo.Norwegian,
+		"zh":      discordgo.ChineseCN,
+		"zh-Hant": discordgo.ChineseTW,
+		"ja-JP":   discordgo.Japanese,
+	} {
+		parsed, found := parseLocale(name)
+		assert.True(t, found, name)
+		assert.Equal(t, expected, parsed, name)
+	}
+
+	for _, name := range []string{"", "xx", "french", "zh-MO", "-FR", "fr-", "id"} {
+		_, found := parseLocale(name)
+		assert.False(t, found, name)
+	}
+}
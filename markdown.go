@@ -0,0 +1,96 @@
+package discordgoi18n
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// markdownInline are escaped anywhere: backslash, bold, italic, underline, code,
+	// strikethrough, spoiler and masked links.
+	markdownInline = "\\*_`~|[]"
+	markdownEscape = '\\'
+	maxHeaderLevel = 3
+)
+
+// escapeMarkdown formats value and escapes the Discord Markdown it contains, unless value
+// is Markdown.
+func escapeMarkdown(value any) Markdown {
+	if markdown, isMarkdown := value.(Markdown); isMarkdown {
+		return markdown
+	}
+
+	return Markdown(escapeMarkdownText(fmt.Sprint(value)))
+}
+
+// escapeMarkdownText escapes inline Discord Markdown characters, plus quotes, headers, subtexts and
+// lists at the beginning of each line.
+func escapeMarkdownText(text string) string {
+	var buf strings.Builder
+	buf.Grow(len(text))
+
+	lineStart := true
+	escapeAt := -1
+	for i, char := range text {
+		if lineStart {
+			escapeAt = -1
+			if offset, found := blockMarkdownOffset(text[i:]); found {
+				escapeAt = i + offset
+			}
+		}
+
+		if i == escapeAt || strings.ContainsRune(markdownInline, char) {
+			buf.WriteRune(markdownEscape)
+		}
+
+		buf.WriteRune(char)
+		switch char {
+		case '\n':
+			lineStart = true
+		case ' ', '\t':
+		default:
+			lineStart = false
+		}
+	}
+
+	return buf.String()
+}
+
+// blockMarkdownOffset returns the offset of the punctuation to escape when text starts with
+// a quote, a header, a subtext or a list item. Discord only takes backslashes before punctuation
+// as escapes, so numbered list items are escaped on their dot.
+func blockMarkdownOffset(text string) (int, bool) {
+	switch {
+	case strings.HasPrefix(text, "> "), strings.HasPrefix(text, ">>> "):
+		return 0, true
+	case strings.HasPrefix(text, "- "), strings.HasPrefix(text, "-# "):
+		return 0, true
+	}
+
+	// Headers from "# " to "### " and numbered list items such as "1. "
+	headers := strings.TrimLeft(text, "#")
+	if level := len(text) - len(headers); level > 0 {
+		return 0, level <= maxHeaderLevel && strings.HasPrefix(headers, " ")
+	}
+
+	digits := strings.TrimLeft(text, "0123456789")
+	return len(text) - len(digits), len(digits) < len(text) && strings.HasPrefix(digits, ". ")
+}
+
+// markup turns a function returning Discord markup into one returning Markdown, so that its
+// result is never escaped.
+func markup(format func(value any) (string, error)) func(value any) (Markdown, error) {
+	return func(value any) (Markdown, error) {
+		formatted, err := format(value)
+		return Markdown(formatted), err
+	}
+}
+
+// raw marks value as Markdown to inject it without escaping.
+func raw(value any) Markdown {
+	if markdown, isMarkdown := value.(Markdown); isMarkdown {
+		return markdown
+	}
+
+	return Markdown(fmt.Sprint(value))
+}
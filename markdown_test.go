@@ -0,0 +1,44 @@
+package discordgoi18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test escaping Discord Markdown
+func TestEscapeMarkdownText(t *testing.T) {
+	for text, expected := range map[string]string{
+		"Nick":                 "Nick",
+		"**admin**":            "\\*\\*admin\\*\\*",
+		"__under__ ~~strike~~": "\\_\\_under\\_\\_ \\~\\~strike\\~\\~",
+		"`code` ```block```":   "\\`code\\` \\`\\`\\`block\\`\\`\\`",
+		"||spoiler||":          "\\|\\|spoiler\\|\\|",
+		"[link](https://x.y)":  "\\[link\\](https://x.y)",
+		"back\\slash":          "back\\\\slash",
+		"> quote":              "\\> quote",
+		">>> quote":            "\\>>> quote",
+		"a > b":                "a > b",
+		"# title":              "\\# title",
+		"### title":            "\\### title",
+		"#### title":           "#### title",
+		"#general":             "#general",
+		"-# subtext":           "\\-# subtext",
+		"- item":               "\\- item",
+		"-1":                   "-1",
+		"12. item":             "12\\. item",
+		"1. hi\n 2. ho":        "1\\. hi\n 2\\. ho",
+		"12.5":                 "12.5",
+		"line\n  # title":      "line\n  \\# title",
+		"<@80351110224678912>": "<@80351110224678912>",
+		"ニック":                  "ニック",
+	} {
+		assert.Equal(t, expected, escapeMarkdownText(text), text)
+	}
+
+	assert.Equal(t, Markdown("\\*\\*12\\*\\*"), escapeMarkdown("**12**"))
+	assert.Equal(t, Markdown("12"), escapeMarkdown(12))
+	assert.Equal(t, Markdown("**12**"), escapeMarkdown(Markdown("**12**")))
+	assert.Equal(t, Markdown("**12**"), raw("**12**"))
+	assert.Equal(t, Markdown("**12**"), raw(Markdown("**12**")))
+}
@@ -0,0 +1,67 @@
+package discordgoi18n
+
+import (
+	"fmt"
+	"slices"
+)
+
+// withLayer returns a copy of layers with layer, either replacing the layer previously loaded
+// from the same path or appended as the last one.
+func withLayer(layers []bundleLayer, layer bundleLayer) []bundleLayer {
+	layers = slices.Clone(layers)
+	for i := range layers {
+		if layers[i].cachePath == layer.cachePath {
+			layers[i] = layer
+			return layers
+		}
+	}
+
+	return append(layers, layer)
+}
+
+// mergeLayers merges the bundles of layers in their loading order, resolving keys declared by
+// several layers with policy. It returns the merged bundle along with the sorted keys whose
+// translations have been overwritten or ignored.
+func mergeLayers(layers []bundleLayer, policy ConflictPolicy) (bundle, []string, error) {
+	switch policy {
+	case ConflictError, ConflictFirstWins, ConflictLastWins:
+	default:
+		return nil, nil, fmt.Errorf("unknown conflict policy '%s'", policy)
+	}
+
+	if len(layers) == 1 {
+		return layers[0].bundle, nil, nil
+	}
+
+	merged := make(bundle)
+	origins := make(map[string]string)
+	var overwritten []string
+	for _, layer := range layers {
+		for key, entry := range layer.bundle {
+			origin, found := origins[key]
+			if found {
+				switch policy {
+				case ConflictError:
+					return nil, nil, fmt.Errorf("key '%s' of '%s' is already declared by '%s'",
+						key, layer.cachePath, origin)
+				case ConflictFirstWins:
+					overwritten = append(overwritten, key)
+					continue
+				case ConflictLastWins:
+					overwritten = append(overwritten, key)
+				}
+			}
+
+			merged[key] = entry
+			origins[key] = layer.cachePath
+		}
+	}
+
+	// References between keys of different layers may only loop once merged
+	if err := merged.checkReferences(); err != nil {
+		return nil, nil, err
+	}
+
+	slices.Sort(overwritten)
+	return merged, slices.Compact(overwritten), nil
+}
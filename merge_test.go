@@ -0,0 +1,53 @@
+package discordgoi18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test replacing and appending bundle layers
+func TestWithLayer(t *testing.T) {
+	first, second := bundle{"a": &entry{}}, bundle{"b": &entry{}}
+	layers := withLayer(nil, bundleLayer{cachePath: "first", bundle: first})
+	assert.Equal(t, []bundleLayer{{cachePath: "first", bundle: first}}, layers)
+
+	layers = withLayer(layers, bundleLayer{cachePath: "second", bundle: second})
+	assert.Equal(t, []string{"first", "second"}, []string{layers[0].cachePath, layers[1].cachePath})
+
+	reloaded := withLayer(layers, bundleLayer{cachePath: "first", bundle: second})
+	assert.Len(t, reloaded, 2)
+	assert.Equal(t, second, reloaded[0].bundle)
+	assert.Equal(t, first, layers[0].bundle)
+}
+
+// Test merging bundle layers with every conflict policy
+func TestMergeLayers(t *testing.T) {
+	first := &entry{messages: []*message{{raw: "first"}}}
+	last := &entry{messages: []*message{{raw: "last"}}}
+	layers := []bundleLayer{
+		{cachePath: "commands", bundle: bundle{"ping": first, "pong": first, "help": first}},
+		{cachePath: "errors", bundle: bundle{"ping": last, "help": last, "error": last}},
+	}
+
+	merged, overwritten, err := mergeLayers(layers, ConflictFirstWins)
+	assert.NoError(t, err)
+	assert.Equal(t, bundle{"ping": first, "pong": first, "help": first, "error": last}, merged)
+	assert.Equal(t, []string{"help", "ping"}, overwritten)
+
+	merged, overwritten, err = mergeLayers(layers, ConflictLastWins)
+	assert.NoError(t, err)
+	assert.Equal(t, bundle{"ping": last, "pong": first, "help": last, "error": last}, merged)
+	assert.Equal(t, []string{"help", "ping"}, overwritten)
+
+	_, _, err = mergeLayers(layers, ConflictError)
+	assert.ErrorContains(t, err, "already declared by 'commands'")
+
+	_, _, err = mergeLayers(layers, "unknown")
+	assert.Error(t, err)
+
+	merged, overwritten, err = mergeLayers(layers[:1], ConflictError)
+	assert.NoError(t, err)
+	assert.Equal(t, layers[0].bundle, merged)
+	assert.Empty(t, overwritten)
+}
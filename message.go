@@ -0,0 +1,294 @@
+package discordgoi18n
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+	"text/template"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	leftDelim       = "{{"
+	rightDelim      = "}}"
+	executionPolicy = "missingkey=error"
+)
+
+// newMessages compiles a bundle value, either a single raw or an array of raws picked randomly.
+// In case any other type is provided, it is mapped to string.
+func newMessages(key string, content any, options compileOptions) ([]*message, error) {
+	var values []any
+	switch value := content.(type) {
+	case []any:
+		values = value
+	case []string:
+		values = make([]any, 0, len(value))
+		for _, raw := range value {
+			values = append(values, raw)
+		}
+	default:
+		values = []any{content}
+	}
+
+	messages := make([]*message, 0, len(values))
+	for _, value := range values {
+		raw, isString := value.(string)
+		if !isString {
+			raw = fmt.Sprintf("%v", value)
+		}
+
+		msg, err := newMessage(key, raw, options)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, msg)
+	}
+
+	return messages, nil
+}
+
+// newPluralEntry compiles every plural form of content, each form being a raw or an array of raws.
+// Forms are selected with the cardinal or ordinal rules content declares; the other form is
+// mandatory since it is used for any count without a dedicated form.
+func newPluralEntry(key string, content map[string]any, options compileOptions) (*entry, error) {
+	if _, found := content[string(pluralOther)]; !found {
+		return nil, fmt.Errorf("plural object of key '%s' does not declare the '%s' form", key, pluralOther)
+	}
+
+	pluralEntry := &entry{plurals: make(map[pluralCategory][]*message, len(content)-1)}
+	for category, value := range content {
+		switch {
+		case category == pluralKey:
+			switch value {
+			case pluralTypeCardinal:
+			case pluralTypeOrdinal:
+				pluralEntry.ordinal = true
+			default:
+				return nil, fmt.Errorf("plural type '%v' of key '%s' is neither '%s' nor '%s'",
+					value, key, pluralTypeCardinal, pluralTypeOrdinal)
+			}
+			continue
+		case !isPluralCategory(category):
+			return nil, fmt.Errorf("plural form '%s' of key '%s' is not a CLDR plural category", category, key)
+		}
+
+		messages, err := newMessages(key, value, options)
+		if err != nil {
+			return nil, err
+		}
+		pluralEntry.plurals[pluralCategory(category)] = messages
+	}
+
+	return pluralEntry, nil
+}
+
+// allMessages returns the messages of the entry, including every plural form and case.
+func (entry *entry) allMessages() []*message {
+	messages := entry.messages
+	for _, forms := range entry.plurals {
+		messages = append(messages, forms...)
+	}
+	for _, selectCase := range entry.cases {
+		messages = append(messages, selectCase.allMessages()...)
+	}
+
+	return messages
+}
+
+func (entry *entry) isEmpty() bool {
+	if entry.cases != nil {
+		return entry.cases[selectOther].isEmpty()
+	}
+
+	return len(entry.messages) == 0 && len(entry.plurals[pluralOther]) == 0
+}
+
+// resolve returns the messages of the entry; for select entries, the case is chosen with
+// variables first. For plural entries, the form matching count is selected with the cardinal
+// or ordinal rule of locale. The zero form, when provided, takes precedence for a count of 0
+// and the other form is used when count is nil or its form is not provided.
+func (entry *entry) resolve(locale discordgo.Locale, count any, variables Vars) ([]*message, error) {
+	if entry.cases != nil {
+		return entry.resolveCase(locale, count, variables)
+	}
+
+	if entry.plurals == nil {
+		return entry.messages, nil
+	}
+
+	if count == nil {
+		return entry.plurals[pluralOther], nil
+	}
+
+	operands, err := newPluralOperands(count)
+	if err != nil {
+		return nil, err
+	}
+
+	rule := cardinalRule(locale)
+	if entry.ordinal {
+		rule = ordinalRule(locale)
+	}
+
+	category := rule(operands)
+	if _, found := entry.plurals[pluralZero]; found && operands.nEquals(0) {
+		category = pluralZero
+	}
+
+	if messages, found := entry.plurals[category]; found {
+		return messages, nil
+	}
+
+	return entry.plurals[pluralOther], nil
+}
+
+// newMessage compiles raw once for all with the given options; raws without any
+// action are kept as plain strings.
+func newMessage(key, raw string, options compileOptions) (*message, error) {
+	switch options.syntax {
+	case SyntaxICU:
+		return newICUMessage(key, raw, options)
+	case SyntaxTemplate:
+	default:
+		return nil, fmt.Errorf("unknown syntax '%s' for key '%s'", options.syntax, key)
+	}
+
+	if !strings.Contains(raw, leftDelim) {
+		return &message{raw: raw}, nil
+	}
+
+	// Functions are bound to the rendering locale later on, default ones are only used to parse.
+	t := template.New(key).Delims(leftDelim, rightDelim).Option(executionPolicy).Funcs(options.funcs.parseFuncs())
+	if options.partials != nil {
+		if options.partials.Lookup(key) != nil {
+			return nil, fmt.Errorf("key '%s' is already declared as partial or constant", key)
+		}
+
+		// Clone never fails on templates which have not been executed
+		shared, _ := options.partials.Clone()
+		t = shared.New(key)
+	}
+
+	t, err := t.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse raw corresponding to key '%s': %w", key, err)
+	}
+
+	includes, err := checkPartialCalls(t, options.partials)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse raw corresponding to key '%s': %w", key, err)
+	}
+
+	if options.sanitizer.enabled() {
+		sanitizeTemplate(t, options.sanitizer, options.partials)
+	}
+
+	compiled := &message{raw: raw, template: t, funcs: options.funcs, references: options.references}
+	compiled.nested = includes || len(compiled.referencedKeys()) > 0
+	return compiled, nil
+}
+
+// newICUMessage parses raw as an ICU message; raws without any syntax character
+// are kept as plain strings.
+func newICUMessage(key, raw string, options compileOptions) (*message, error) {
+	if !strings.ContainsAny(raw, "{}'") {
+		return &message{raw: raw}, nil
+	}
+
+	icu, err := parseICUMessage(raw)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse raw corresponding to key '%s': %w", key, err)
+	}
+
+	return &message{raw: raw, icu: icu, sanitizer: options.sanitizer}, nil
+}
+
+// render injects variables in the message, values being formatted in locale and plural
+// arguments relying on the rules of bundleLocale, the locale the message comes from.
+// Plain messages or messages with arguments rendered without variables are returned as is.
+// depth is the number of nested translations the message is rendered in.
+func (message *message) render(locale, bundleLocale discordgo.Locale, variables Vars, depth int) (string, error) {
+	if message.icu != nil {
+		if variables == nil && message.icu.hasArguments() {
+			return message.raw, nil
+		}
+
+		var buf strings.Builder
+		err := message.icu.format(&buf, &icuContext{
+			locale:       locale,
+			pluralLocale: bundleLocale,
+			format:       newNumberFormat(locale),
+			variables:    variables,
+			sanitizer:    message.sanitizer,
+		})
+		if err != nil {
+			return "", err
+		}
+
+		return buf.String(), nil
+	}
+
+	// Messages referencing other keys or partials are rendered even without variables
+	if message.template == nil || variables == nil && !message.nested {
+		return message.raw, nil
+	}
+
+	var buf strings.Builder
+	err := message.localizedTemplate(locale, depth).Execute(&buf, variables)
+	if err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+// localizedTemplate returns the message template with functions bound to locale and depth.
+// Templates are cloned once per locale and depth then cached, using copy-on-write like the
+// translator state.
+func (message *message) localizedTemplate(locale discordgo.Locale, depth int) *template.Template {
+	key := templateKey{locale: locale, depth: depth}
+	for {
+		current := message.templates.Load()
+		if current != nil {
+			if localized, found := (*current)[key]; found {
+				return localized
+			}
+		}
+
+		funcs := message.funcs.localizedFuncs(locale)
+		if message.references != nil {
+			funcs[referenceFuncName] = referenceFunc(message.references, locale, depth)
+		}
+
+		// Clone never fails on text/template templates
+		localized, _ := message.template.Clone()
+		localized.Funcs(funcs)
+
+		templates := make(map[templateKey]*template.Template)
+		if current != nil {
+			templates = maps.Clone(*current)
+		}
+		templates[key] = localized
+
+		if message.templates.CompareAndSwap(current, &templates) {
+			return localized
+		}
+	}
+}
+
+// withVariable returns a copy of variables containing value under name, unless variables
+// already defines it.
+func withVariable(variables Vars, name string, value any) Vars {
+	if _, found := variables[name]; found {
+		return variables
+	}
+
+	copied := make(Vars, len(variables)+1)
+	for key, variable := range variables {
+		copied[key] = variable
+	}
+	copied[name] = value
+
+	return copied
+}
@@ -0,0 +1,79 @@
+package discordgoi18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test compiling raws into messages
+func TestNewMessage(t *testing.T) {
+	// Plain raw: no template compiled
+	msg, err := newMessage("plain", "Hello world!", compileOptions{syntax: SyntaxTemplate})
+	assert.NoError(t, err)
+	assert.Equal(t, "Hello world!", msg.raw)
+	assert.Nil(t, msg.template)
+
+	// Raw with actions: template compiled once
+	msg, err = newMessage("hello", "Hello {{ .anyone }}!", compileOptions{syntax: SyntaxTemplate})
+	assert.NoError(t, err)
+	assert.Equal(t, "Hello {{ .anyone }}!", msg.raw)
+	assert.NotNil(t, msg.template)
+
+	// Invalid template: error reporting the key
+	msg, err = newMessage("parse", "{{if $foo}}{{end}}", compileOptions{syntax: SyntaxTemplate})
+	assert.ErrorContains(t, err, "parse")
+	assert.Nil(t, msg)
+}
+
+// Test compiling bundle values into messages
+func TestNewMessages(t *testing.T) {
+	options := compileOptions{syntax: SyntaxTemplate}
+	for content, expected := range map[string]any{
+		"raw":     "Hello",
+		"any":     []any{"Hello", "Hi"},
+		"strings": []string{"Hello", "Hi"},
+		"number":  42,
+	} {
+		messages, err := newMessages(content, expected, options)
+		assert.NoError(t, err, content)
+
+		raws := make([]string, 0, len(messages))
+		for _, msg := range messages {
+			raws = append(raws, msg.raw)
+		}
+		switch content {
+		case "raw":
+			assert.Equal(t, []string{"Hello"}, raws)
+		case "number":
+			assert.Equal(t, []string{"42"}, raws)
+		default:
+			assert.Equal(t, []string{"Hello", "Hi"}, raws, content)
+		}
+	}
+}
+
+// Test rendering messages
+func TestMessageRender(t *testing.T) {
+	plain, err := newMessage("plain", "Hello world!", compileOptions{syntax: SyntaxTemplate})
+	assert.NoError(t, err)
+	hello, err := newMessage("hello", "Hello {{ .anyone }}!", compileOptions{syntax: SyntaxTemplate})
+	assert.NoError(t, err)
+
+	translation, err := plain.render(defaultLocale, defaultLocale, Vars{"anyone": "Nick"}, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "Hello world!", translation)
+
+	// No variables: raw returned as is
+	translation, err = hello.render(defaultLocale, defaultLocale, nil, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "Hello {{ .anyone }}!", translation)
+
+	translation, err = hello.render(defaultLocale, defaultLocale, Vars{"anyone": "Nick"}, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "Hello Nick!", translation)
+
+	// Missing variable
+	_, err = hello.render(defaultLocale, defaultLocale, Vars{}, 0)
+	assert.Error(t, err)
+}
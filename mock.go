@@ -2,7 +2,9 @@ package discordgoi18n
 
 import (
 	"errors"
+	"io"
 	"io/fs"
+	"text/template"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/kstoums/discordgo-i18n/logger"
@@ -23,6 +25,69 @@ func (mock *translatorMock) SetDefault(locale discordgo.Locale) {
 	}
 }
 
+func (mock *translatorMock) SetFallbacks(locale discordgo.Locale, fallbacks ...discordgo.Locale) {
+	if mock.SetFallbacksFunc != nil {
+		mock.SetFallbacksFunc(locale, fallbacks...)
+		return
+	}
+}
+
+func (mock *translatorMock) SetSyntax(syntax Syntax) {
+	if mock.SetSyntaxFunc != nil {
+		mock.SetSyntaxFunc(syntax)
+		return
+	}
+}
+
+func (mock *translatorMock) SetMarkdownEscaping(enabled bool) {
+	if mock.SetMarkdownEscapingFunc != nil {
+		mock.SetMarkdownEscapingFunc(enabled)
+		return
+	}
+}
+
+func (mock *translatorMock) SetMentionNeutralization(enabled bool) {
+	if mock.SetMentionNeutralizationFunc != nil {
+		mock.SetMentionNeutralizationFunc(enabled)
+		return
+	}
+}
+
+func (mock *translatorMock) SetLoadMode(mode LoadMode) {
+	if mock.SetLoadModeFunc != nil {
+		mock.SetLoadModeFunc(mode)
+		return
+	}
+}
+
+func (mock *translatorMock) SetConflictPolicy(policy ConflictPolicy) {
+	if mock.SetConflictPolicyFunc != nil {
+		mock.SetConflictPolicyFunc(policy)
+		return
+	}
+}
+
+func (mock *translatorMock) SetLocaleValidation(validation LocaleValidation) {
+	if mock.SetLocaleValidationFunc != nil {
+		mock.SetLocaleValidationFunc(validation)
+		return
+	}
+}
+
+func (mock *translatorMock) AddFuncs(funcs template.FuncMap) error {
+	if mock.AddFuncsFunc != nil {
+		return mock.AddFuncsFunc(funcs)
+	}
+	return errors.New("AddFuncs not mocked")
+}
+
+func (mock *translatorMock) AddLocaleFuncs(locale discordgo.Locale, funcs template.FuncMap) error {
+	if mock.AddLocaleFuncsFunc != nil {
+		return mock.AddLocaleFuncsFunc(locale, funcs)
+	}
+	return errors.New("AddLocaleFuncs not mocked")
+}
+
 func (mock *translatorMock) LoadBundle(locale discordgo.Locale, file string) error {
 	if mock.LoadBundleFunc != nil {
 		return mock.LoadBundleFunc(locale, file)
@@ -37,6 +102,20 @@ func (mock *translatorMock) LoadBundleFS(locale discordgo.Locale, fs fs.FS, file
 	return errors.New("LoadBundleFS not mocked")
 }
 
+func (mock *translatorMock) LoadBundleFormat(locale discordgo.Locale, file string, format Format) error {
+	if mock.LoadBundleFormatFunc != nil {
+		return mock.LoadBundleFormatFunc(locale, file, format)
+	}
+	return errors.New("LoadBundleFormat not mocked")
+}
+
+func (mock *translatorMock) LoadBundleFSFormat(locale discordgo.Locale, fs fs.FS, file string, format Format) error {
+	if mock.LoadBundleFSFormatFunc != nil {
+		return mock.LoadBundleFSFormatFunc(locale, fs, file, format)
+	}
+	return errors.New("LoadBundleFSFormat not mocked")
+}
+
 func (mock *translatorMock) LoadBundleContent(locale discordgo.Locale, content map[string]any) error {
 	if mock.LoadBundleContentFunc != nil {
 		return mock.LoadBundleContentFunc(locale, content)
@@ -44,6 +123,63 @@ func (mock *translatorMock) LoadBundleContent(locale discordgo.Locale, content m
 	return errors.New("LoadBundleContent not mocked")
 }
 
+func (mock *translatorMock) LoadDir(dir string) error {
+	if mock.LoadDirFunc != nil {
+		return mock.LoadDirFunc(dir)
+	}
+	return errors.New("LoadDir not mocked")
+}
+
+func (mock *translatorMock) LoadDirFS(fs fs.FS, dir string) error {
+	if mock.LoadDirFSFunc != nil {
+		return mock.LoadDirFSFunc(fs, dir)
+	}
+	return errors.New("LoadDirFS not mocked")
+}
+
+func (mock *translatorMock) LoadGettext(locale discordgo.Locale, path string, fuzzy bool) error {
+	if mock.LoadGettextFunc != nil {
+		return mock.LoadGettextFunc(locale, path, fuzzy)
+	}
+	return errors.New("LoadGettext not mocked")
+}
+
+func (mock *translatorMock) LoadGettextFS(locale discordgo.Locale, fs fs.FS, path string, fuzzy bool) error {
+	if mock.LoadGettextFSFunc != nil {
+		return mock.LoadGettextFSFunc(locale, fs, path, fuzzy)
+	}
+	return errors.New("LoadGettextFS not mocked")
+}
+
+func (mock *translatorMock) LoadXLIFF(locale discordgo.Locale, path string) error {
+	if mock.LoadXLIFFFunc != nil {
+		return mock.LoadXLIFFFunc(locale, path)
+	}
+	return errors.New("LoadXLIFF not mocked")
+}
+
+func (mock *translatorMock) LoadXLIFFFS(locale discordgo.Locale, fs fs.FS, path string) error {
+	if mock.LoadXLIFFFSFunc != nil {
+		return mock.LoadXLIFFFSFunc(locale, fs, path)
+	}
+	return errors.New("LoadXLIFFFS not mocked")
+}
+
+func (mock *translatorMock) ExportXLIFF(w io.Writer, source, target discordgo.Locale, version XLIFFVersion) error {
+	if mock.ExportXLIFFFunc != nil {
+		return mock.ExportXLIFFFunc(w, source, target, version)
+	}
+	return errors.New("ExportXLIFF not mocked")
+}
+
+func (mock *translatorMock) GetOverwrittenKeys(locale discordgo.Locale) []string {
+	if mock.GetOverwrittenKeysFunc != nil {
+		return mock.GetOverwrittenKeysFunc(locale)
+	}
+
+	return nil
+}
+
 func (mock *translatorMock) Get(locale discordgo.Locale, key string, variables Vars) string {
 	if mock.GetFunc != nil {
 		return mock.GetFunc(locale, key, variables)
@@ -60,6 +196,14 @@ func (mock *translatorMock) GetArray(locale discordgo.Locale, key string, variab
 	return []string{key}
 }
 
+func (mock *translatorMock) GetPlural(locale discordgo.Locale, key string, count any, variables Vars) string {
+	if mock.GetPluralFunc != nil {
+		return mock.GetPluralFunc(locale, key, count, variables)
+	}
+
+	return key
+}
+
 func (mock *translatorMock) GetDefault(key string, variables Vars) string {
 	if mock.GetDefaultFunc != nil {
 		return mock.GetDefaultFunc(key, variables)
@@ -1,9 +1,12 @@
 package discordgoi18n
 
 import (
+	"io"
 	"io/fs"
+	"strings"
 	"testing"
 	"testing/fstest"
+	"text/template"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/stretchr/testify/assert"
@@ -17,6 +20,46 @@ func TestMock(t *testing.T) {
 		assert.Equal(t, discordgo.EnglishUS, locale)
 	}
 
+	mock.SetFallbacksFunc = func(locale discordgo.Locale, fallbacks ...discordgo.Locale) {
+		assert.Equal(t, discordgo.SpanishLATAM, locale)
+		assert.Equal(t, []discordgo.Locale{discordgo.SpanishES}, fallbacks)
+	}
+
+	mock.SetSyntaxFunc = func(syntax Syntax) {
+		assert.Equal(t, SyntaxICU, syntax)
+	}
+
+	mock.SetMarkdownEscapingFunc = func(enabled bool) {
+		assert.True(t, enabled)
+	}
+
+	mock.SetMentionNeutralizationFunc = func(enabled bool) {
+		assert.True(t, enabled)
+	}
+
+	mock.SetLoadModeFunc = func(mode LoadMode) {
+		assert.Equal(t, LoadModeMerge, mode)
+	}
+
+	mock.SetConflictPolicyFunc = func(policy ConflictPolicy) {
+		assert.Equal(t, ConflictLastWins, policy)
+	}
+
+	mock.SetLocaleValidationFunc = func(validation LocaleValidation) {
+		assert.Equal(t, LocaleValidationStrict, validation)
+	}
+
+	mock.AddFuncsFunc = func(funcs template.FuncMap) error {
+		assert.Contains(t, funcs, "upper")
+		return nil
+	}
+
+	mock.AddLocaleFuncsFunc = func(locale discordgo.Locale, funcs template.FuncMap) error {
+		assert.Equal(t, discordgo.French, locale)
+		assert.Contains(t, funcs, "upper")
+		return nil
+	}
+
 	mock.LoadBundleFunc = func(locale discordgo.Locale, file string) error {
 		assert.Equal(t, discordgo.French, locale)
 		assert.Equal(t, "file.json", file)
@@ -30,12 +73,79 @@ func TestMock(t *testing.T) {
 		return nil
 	}
 
+	mock.LoadBundleFormatFunc = func(locale discordgo.Locale, file string, format Format) error {
+		assert.Equal(t, discordgo.French, locale)
+		assert.Equal(t, "file.txt", file)
+		assert.Equal(t, FormatYAML, format)
+		return nil
+	}
+
+	mock.LoadBundleFSFormatFunc = func(locale discordgo.Locale, f fs.FS, file string, format Format) error {
+		assert.Equal(t, discordgo.German, locale)
+		assert.NotNil(t, f)
+		assert.Equal(t, "bundle.txt", file)
+		assert.Equal(t, FormatYAML, format)
+		return nil
+	}
+
 	mock.LoadBundleContentFunc = func(locale discordgo.Locale, content map[string]any) error {
 		assert.Equal(t, discordgo.Italian, locale)
 		assert.NotNil(t, content)
 		return nil
 	}
 
+	mock.LoadDirFunc = func(dir string) error {
+		assert.Equal(t, "locales", dir)
+		return nil
+	}
+
+	mock.LoadDirFSFunc = func(f fs.FS, dir string) error {
+		assert.NotNil(t, f)
+		assert.Equal(t, ".", dir)
+		return nil
+	}
+
+	mock.LoadGettextFunc = func(locale discordgo.Locale, path string, fuzzy bool) error {
+		assert.Equal(t, discordgo.Italian, locale)
+		assert.Equal(t, "it.po", path)
+		assert.True(t, fuzzy)
+		return nil
+	}
+
+	mock.LoadGettextFSFunc = func(locale discordgo.Locale, f fs.FS, path string, fuzzy bool) error {
+		assert.Equal(t, discordgo.Italian, locale)
+		assert.NotNil(t, f)
+		assert.Equal(t, "it.mo", path)
+		assert.False(t, fuzzy)
+		return nil
+	}
+
+	mock.LoadXLIFFFunc = func(locale discordgo.Locale, path string) error {
+		assert.Equal(t, discordgo.Italian, locale)
+		assert.Equal(t, "it.xlf", path)
+		return nil
+	}
+
+	mock.LoadXLIFFFSFunc = func(locale discordgo.Locale, f fs.FS, path string) error {
+		assert.Equal(t, discordgo.Italian, locale)
+		assert.NotNil(t, f)
+		assert.Equal(t, "it.xlf", path)
+		return nil
+	}
+
+	mock.ExportXLIFFFunc = func(w io.Writer, source, target discordgo.Locale, version XLIFFVersion) error {
+		assert.NotNil(t, w)
+		assert.Equal(t, discordgo.EnglishUS, source)
+		assert.Equal(t, discordgo.Italian, target)
+		assert.Equal(t, XLIFFVersion20, version)
+		return nil
+	}
+
+	mock.GetOverwrittenKeysFunc = func(locale discordgo.Locale) []string {
+		assert.Equal(t, discordgo.Italian, locale)
+		return []string{"hi"}
+	}
+
 	mock.GetFunc = func(locale discordgo.Locale, key string, variables Vars) string {
 		if key == "fail" {
 			return key
@@ -54,6 +164,16 @@ func TestMock(t *testing.T) {
 		return []string{"lunes", "martes"}
 	}
 
+	mock.GetPluralFunc = func(locale discordgo.Locale, key string, count any, variables Vars) string {
+		if key == "fail_plural" {
+			return key
+		}
+		assert.Equal(t, discordgo.Polish, locale)
+		assert.Equal(t, "coins", key)
+		assert.Equal(t, 5, count)
+		return "5 monet"
+	}
+
 	mock.GetDefaultFunc = func(key string, variables Vars) string {
 		if key == "fail_default" {
 			return key
@@ -83,12 +203,33 @@ func TestMock(t *testing.T) {
 
 	assert.NotPanics(t, func() { mock.SetDefault(discordgo.EnglishUS) })
 
+	assert.NotPanics(t, func() { mock.SetFallbacks(discordgo.SpanishLATAM, discordgo.SpanishES) })
+
+	assert.NotPanics(t, func() { mock.SetSyntax(SyntaxICU) })
+	assert.NotPanics(t, func() { mock.SetMarkdownEscaping(true) })
+	assert.NotPanics(t, func() { mock.SetMentionNeutralization(true) })
+	assert.NotPanics(t, func() { mock.SetLoadMode(LoadModeMerge) })
+	assert.NotPanics(t, func() { mock.SetConflictPolicy(ConflictLastWins) })
+	assert.NotPanics(t, func() { mock.SetLocaleValidation(LocaleValidationStrict) })
+	assert.NoError(t, mock.AddFuncs(template.FuncMap{"upper": strings.ToUpper}))
+	assert.NoError(t, mock.AddLocaleFuncs(discordgo.French, template.FuncMap{"upper": strings.ToUpper}))
+
 	assert.NoError(t, mock.LoadBundle(discordgo.French, "file.json"))
 
 	fsys := fstest.MapFS{"bundle.json": {Data: []byte(`{"example":"value"}`)}}
 	assert.NoError(t, mock.LoadBundleFS(discordgo.German, fsys, "bundle.json"))
 
+	assert.NoError(t, mock.LoadBundleFormat(discordgo.French, "file.txt", FormatYAML))
+	assert.NoError(t, mock.LoadBundleFSFormat(discordgo.German, fsys, "bundle.txt", FormatYAML))
 	assert.NoError(t, mock.LoadBundleContent(discordgo.Italian, map[string]any{"hi": "ciao"}))
+	assert.NoError(t, mock.LoadDir("locales"))
+	assert.NoError(t, mock.LoadDirFS(fsys, "."))
+	assert.NoError(t, mock.LoadGettext(discordgo.Italian, "it.po", true))
+	assert.NoError(t, mock.LoadGettextFS(discordgo.Italian, fsys, "it.mo", false))
+	assert.NoError(t, mock.LoadXLIFF(discordgo.Italian, "it.xlf"))
+	assert.NoError(t, mock.LoadXLIFFFS(discordgo.Italian, fsys, "it.xlf"))
+	assert.NoError(t, mock.ExportXLIFF(io.Discard, discordgo.EnglishUS, discordgo.Italian, XLIFFVersion20))
+	assert.Equal(t, []string{"hi"}, mock.GetOverwrittenKeys(discordgo.Italian))
 
 	// GET (success)
 	assert.Equal(t, "Hola", mock.Get(discordgo.SpanishES, "greeting", nil))
@@ -104,6 +245,13 @@ func TestMock(t *testing.T) {
 	translations := mock.GetArray(discordgo.SpanishES, "fail_array", nil)
 	assert.Equal(t, []string{"fail_array"}, translations)
 
+	// GET PLURAL (success)
+	assert.Equal(t, "5 monet", mock.GetPlural(discordgo.Polish, "coins", 5, nil))
+
+	// GET PLURAL (error)
+	translation = mock.GetPlural(discordgo.Polish, "fail_plural", 5, nil)
+	assert.Equal(t, "fail_plural", translation)
+
 	// GET DEFAULT (success)
 	assert.Equal(t, "default", mock.GetDefault("key", nil))
 
@@ -0,0 +1,261 @@
+package discordgoi18n
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	nbsp       = "\u00a0"
+	narrowNbsp = "\u202f"
+	minusSign  = "\u2212"
+
+	// numberPlaceholder and currencyPlaceholder are replaced in percent and currency patterns.
+	numberPlaceholder   = "#"
+	currencyPlaceholder = "¤"
+
+	defaultFractionDigits  = 3
+	percentFractionDigits  = 0
+	currencyFractionDigits = 2
+	currencyCodeLength     = 3
+	percentFactor          = 100
+	defaultGrouping        = 3
+	indianSecondaryGroup   = 2
+	minimumGroupingDigits  = 2
+)
+
+// numberFormat holds the CLDR symbols and patterns used to format numbers in a locale.
+type numberFormat struct {
+	decimal           string
+	group             string
+	minus             string
+	secondaryGrouping int // size of groups after the first one, 0 when equal to the first one
+	minimumGrouping   int // minimum number of digits in the first group before grouping applies
+	percentPattern    string
+	currencyPattern   string
+}
+
+// newNumberFormat returns the CLDR number symbols and patterns of locale; unknown
+// locales are formatted as en-US.
+//
+//nolint:exhaustive,funlen // Locales without any format fall back on the en-US one, long but readable.
+func newNumberFormat(locale discordgo.Locale) numberFormat {
+	format := numberFormat{
+		decimal:         ".",
+		group:           ",",
+		minus:           "-",
+		minimumGrouping: 1,
+		percentPattern:  "#%",
+		currencyPattern: "¤#",
+	}
+
+	switch locale {
+	case discordgo.Bulgarian:
+		format.decimal, format.group, format.minimumGrouping = ",", nbsp, minimumGroupingDigits
+		format.currencyPattern = "#" + nbsp + "¤"
+	case discordgo.Croatian, discordgo.Danish, discordgo.German, discordgo.Romanian, discordgo.SpanishES:
+		format.decimal, format.group = ",", "."
+		format.percentPattern, format.currencyPattern = "#"+nbsp+"%", "#"+nbsp+"¤"
+		if locale == discordgo.Croatian {
+			format.minus = minusSign
+		}
+		if locale == discordgo.SpanishES {
+			format.minimumGrouping = minimumGroupingDigits
+		}
+	case discordgo.Czech, discordgo.Russian, discordgo.Lithuanian, discordgo.Finnish, discordgo.Norwegian,
+		discordgo.Swedish:
+		format.decimal, format.group = ",", nbsp
+		format.percentPattern, format.currencyPattern = "#"+nbsp+"%", "#"+nbsp+"¤"
+		if locale == discordgo.Lithuanian || locale == discordgo.Finnish || locale == discordgo.Norwegian ||
+			locale == discordgo.Swedish {
+			format.minus = minusSign
+		}
+	case discordgo.Hungarian, discordgo.Polish, discordgo.Ukrainian:
+		format.decimal, format.group = ",", nbsp
+		format.currencyPattern = "#" + nbsp + "¤"
+		if locale == discordgo.Polish {
+			format.minimumGrouping = minimumGroupingDigits
+		}
+	case discordgo.French:
+		format.decimal, format.group = ",", narrowNbsp
+		format.percentPattern, format.currencyPattern = "#"+narrowNbsp+"%", "#"+nbsp+"¤"
+	case discordgo.Dutch:
+		format.decimal, format.group = ",", "."
+		format.currencyPattern = "¤" + nbsp + "#"
+	case discordgo.PortugueseBR:
+		format.decimal, format.group = ",", "."
+		format.currencyPattern = "¤" + nbsp + "#"
+	case discordgo.Greek, discordgo.Italian, discordgo.Vietnamese:
+		format.decimal, format.group = ",", "."
+		format.currencyPattern = "#" + nbsp + "¤"
+	case discordgo.Turkish:
+		format.decimal, format.group = ",", "."
+		format.percentPattern = "%#"
+	case discordgo.SpanishLATAM:
+		format.percentPattern = "#" + nbsp + "%"
+	case discordgo.Hindi:
+		format.secondaryGrouping = indianSecondaryGroup
+	}
+
+	return format
+}
+
+// currencySymbol returns the narrow CLDR symbol of an ISO 4217 currency code and its
+// number of fraction digits; unknown codes are used as symbol.
+func currencySymbol(code string) (string, int) {
+	switch strings.ToUpper(code) {
+	case "USD":
+		return "$", currencyFractionDigits
+	case "EUR":
+		return "€", currencyFractionDigits
+	case "GBP":
+		return "£", currencyFractionDigits
+	case "JPY":
+		return "¥", 0
+	case "CNY":
+		return "¥", currencyFractionDigits
+	case "TWD":
+		return "NT$", currencyFractionDigits
+	case "KRW":
+		return "₩", 0
+	case "INR":
+		return "₹", currencyFractionDigits
+	case "BRL":
+		return "R$", currencyFractionDigits
+	case "RUB":
+		return "₽", currencyFractionDigits
+	case "UAH":
+		return "₴", currencyFractionDigits
+	case "PLN":
+		return "zł", currencyFractionDigits
+	case "CZK":
+		return "Kč", currencyFractionDigits
+	case "SEK", "NOK", "DKK":
+		return "kr", currencyFractionDigits
+	case "HUF":
+		return "Ft", currencyFractionDigits
+	case "RON":
+		return "lei", currencyFractionDigits
+	case "BGN":
+		return "лв.", currencyFractionDigits
+	case "TRY":
+		return "₺", currencyFractionDigits
+	case "THB":
+		return "฿", currencyFractionDigits
+	case "VND":
+		return "₫", 0
+	case "CHF":
+		return "CHF", currencyFractionDigits
+	default:
+		return strings.ToUpper(code), currencyFractionDigits
+	}
+}
+
+// formatNumber formats value with at most maxFraction fraction digits and at least minFraction.
+func (format numberFormat) formatNumber(value any, minFraction, maxFraction int) (string, error) {
+	digits, err := decimalDigits(value, maxFraction)
+	if err != nil {
+		return "", err
+	}
+
+	negative := strings.HasPrefix(digits, "-")
+	integer, fraction, _ := strings.Cut(strings.TrimPrefix(digits, "-"), ".")
+	fraction = strings.TrimRight(fraction, "0")
+	for len(fraction) < minFraction {
+		fraction += "0"
+	}
+
+	var buf strings.Builder
+	if negative && (strings.Trim(integer, "0") != "" || fraction != "") {
+		buf.WriteString(format.minus)
+	}
+
+	buf.WriteString(format.groupDigits(integer))
+	if fraction != "" {
+		buf.WriteString(format.decimal)
+		buf.WriteString(fraction)
+	}
+
+	return buf.String(), nil
+}
+
+// groupDigits inserts group separators in integer digits.
+func (format numberFormat) groupDigits(integer string) string {
+	if len(integer) < defaultGrouping+format.minimumGrouping {
+		return integer
+	}
+
+	secondary := format.secondaryGrouping
+	if secondary == 0 {
+		secondary = defaultGrouping
+	}
+
+	groups := []string{integer[len(integer)-defaultGrouping:]}
+	rest := integer[:len(integer)-defaultGrouping]
+	for len(rest) > secondary {
+		groups = append([]string{rest[len(rest)-secondary:]}, groups...)
+		rest = rest[:len(rest)-secondary]
+	}
+
+	return rest + format.group + strings.Join(groups, format.group)
+}
+
+// formatPercent formats value as a percentage, 0.25 being 25%.
+func (format numberFormat) formatPercent(value any, fractionDigits int) (string, error) {
+	number, err := toFloat(value)
+	if err != nil {
+		return "", err
+	}
+
+	formatted, err := format.formatNumber(number*percentFactor, 0, fractionDigits)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.Replace(format.percentPattern, numberPlaceholder, formatted, 1), nil
+}
+
+// formatCurrency formats value as an amount of the given ISO 4217 currency. Codes are made of
+// three letters, so that unknown ones written as is never contain Markdown.
+func (format numberFormat) formatCurrency(code string, value any) (string, error) {
+	if len(code) != currencyCodeLength || strings.Trim(strings.ToUpper(code), "ABCDEFGHIJKLMNOPQRSTUVWXYZ") != "" {
+		return "", fmt.Errorf("'%s' is not an ISO 4217 currency code", code)
+	}
+
+	symbol, fractionDigits := currencySymbol(code)
+	formatted, err := format.formatNumber(value, fractionDigits, fractionDigits)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.Replace(strings.Replace(format.currencyPattern, numberPlaceholder, formatted, 1),
+		currencyPlaceholder, symbol, 1), nil
+}
+
+// decimalDigits returns the plain decimal representation of value rounded to maxFraction
+// fraction digits; integers are kept exact.
+func decimalDigits(value any, maxFraction int) (string, error) {
+	switch v := value.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprintf("%d", v), nil
+	case string:
+		if _, err := strconv.ParseFloat(v, 64); err != nil {
+			return "", fmt.Errorf("'%s' is not a number", v)
+		}
+	}
+
+	number, err := toFloat(value)
+	if err != nil {
+		return "", err
+	}
+
+	if math.IsNaN(number) || math.IsInf(number, 0) {
+		return "", fmt.Errorf("'%v' is not a finite number", value)
+	}
+
+	return strconv.FormatFloat(number, 'f', maxFraction, 64), nil
+}
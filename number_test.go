@@ -0,0 +1,144 @@
+package discordgoi18n
+
+import (
+	"math"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test formatting numbers in every Discord locale
+func TestFormatNumber(t *testing.T) {
+	expected := map[discordgo.Locale]string{
+		discordgo.EnglishUS:    "-1,234,567.891",
+		discordgo.EnglishGB:    "-1,234,567.891",
+		discordgo.Bulgarian:    "-1\u00a0234\u00a0567,891",
+		discordgo.ChineseCN:    "-1,234,567.891",
+		discordgo.ChineseTW:    "-1,234,567.891",
+		discordgo.Croatian:     "\u22121.234.567,891",
+		discordgo.Czech:        "-1\u00a0234\u00a0567,891",
+		discordgo.Danish:       "-1.234.567,891",
+		discordgo.Dutch:        "-1.234.567,891",
+		discordgo.Finnish:      "\u22121\u00a0234\u00a0567,891",
+		discordgo.French:       "-1\u202f234\u202f567,891",
+		discordgo.German:       "-1.234.567,891",
+		discordgo.Greek:        "-1.234.567,891",
+		discordgo.Hindi:        "-12,34,567.891",
+		discordgo.Hungarian:    "-1\u00a0234\u00a0567,891",
+		discordgo.Italian:      "-1.234.567,891",
+		discordgo.Japanese:     "-1,234,567.891",
+		discordgo.Korean:       "-1,234,567.891",
+		discordgo.Lithuanian:   "\u22121\u00a0234\u00a0567,891",
+		discordgo.Norwegian:    "\u22121\u00a0234\u00a0567,891",
+		discordgo.Polish:       "-1\u00a0234\u00a0567,891",
+		discordgo.PortugueseBR: "-1.234.567,891",
+		discordgo.Romanian:     "-1.234.567,891",
+		discordgo.Russian:      "-1\u00a0234\u00a0567,891",
+		discordgo.SpanishES:    "-1.234.567,891",
+		discordgo.SpanishLATAM: "-1,234,567.891",
+		discordgo.Swedish:      "\u22121\u00a0234\u00a0567,891",
+		discordgo.Thai:         "-1,234,567.891",
+		discordgo.Turkish:      "-1.234.567,891",
+		discordgo.Ukrainian:    "-1\u00a0234\u00a0567,891",
+		discordgo.Vietnamese:   "-1.234.567,891",
+		discordgo.Unknown:      "-1,234,567.891",
+	}
+
+	for locale := range discordgo.Locales {
+		formatted, err := newNumberFormat(locale).formatNumber(-1234567.8912, 0, defaultFractionDigits)
+		assert.NoError(t, err)
+		assert.Equal(t, expected[locale], formatted, locale)
+	}
+}
+
+// Test formatting edge cases of numbers
+func TestFormatNumberCases(t *testing.T) {
+	english := newNumberFormat(discordgo.EnglishUS)
+	for expected, value := range map[string]any{
+		"0":                         0,
+		"12":                        12,
+		"999":                       999,
+		"1,000":                     1000,
+		"1.5":                       1.5,
+		"0.333":                     1.0 / 3,
+		"9,223,372,036,854,775,807": int64(math.MaxInt64),
+		"1,234.5":                   "1234.5",
+		"-42":                       int8(-42),
+		"0.001":                     0.001,
+		"42.1":                      float32(42.1),
+	} {
+		formatted, err := english.formatNumber(value, 0, defaultFractionDigits)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, formatted, value)
+	}
+
+	// Negative zero after rounding
+	formatted, err := english.formatNumber(-0.0001, 0, defaultFractionDigits)
+	assert.NoError(t, err)
+	assert.Equal(t, "0", formatted)
+
+	// Minimum fraction digits
+	formatted, err = english.formatNumber(3, 2, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, "3.00", formatted)
+
+	// Minimum grouping digits
+	spanish := newNumberFormat(discordgo.SpanishES)
+	formatted, err = spanish.formatNumber(1234, 0, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "1234", formatted)
+	formatted, err = spanish.formatNumber(12345, 0, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "12.345", formatted)
+
+	for _, value := range []any{"abc", math.NaN(), math.Inf(1), nil, true} {
+		_, err = english.formatNumber(value, 0, defaultFractionDigits)
+		assert.Error(t, err, value)
+	}
+}
+
+// Test formatting percentages and currencies
+func TestFormatPercentCurrency(t *testing.T) {
+	for locale, expected := range map[discordgo.Locale][2]string{
+		discordgo.EnglishUS:    {"12%", "$1,234.50"},
+		discordgo.French:       {"12\u202f%", "1\u202f234,50\u00a0$"},
+		discordgo.German:       {"12\u00a0%", "1.234,50\u00a0$"},
+		discordgo.Dutch:        {"12%", "$\u00a01.234,50"},
+		discordgo.Turkish:      {"%12", "$1.234,50"},
+		discordgo.PortugueseBR: {"12%", "$\u00a01.234,50"},
+		discordgo.Hindi:        {"12%", "$1,234.50"},
+	} {
+		format := newNumberFormat(locale)
+		percent, err := format.formatPercent(0.1234, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, expected[0], percent, locale)
+
+		currency, err := format.formatCurrency("usd", 1234.5)
+		assert.NoError(t, err)
+		assert.Equal(t, expected[1], currency, locale)
+	}
+
+	english := newNumberFormat(discordgo.EnglishUS)
+	percent, err := english.formatPercent(0.12345, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "12.3%", percent)
+
+	currency, err := english.formatCurrency("JPY", 1234.5)
+	assert.NoError(t, err)
+	assert.Equal(t, "¥1,234", currency)
+
+	currency, err = english.formatCurrency("XYZ", 3)
+	assert.NoError(t, err)
+	assert.Equal(t, "XYZ3.00", currency)
+
+	for _, code := range []string{"EU", "EURO", "**E", "€"} {
+		_, err = english.formatCurrency(code, 3)
+		assert.ErrorContains(t, err, "is not an ISO 4217 currency code", code)
+	}
+
+	_, err = english.formatPercent("abc", 0)
+	assert.Error(t, err)
+	_, err = english.formatCurrency("EUR", "abc")
+	assert.Error(t, err)
+}
@@ -0,0 +1,191 @@
+package discordgoi18n
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+	"text/template/parse"
+)
+
+const (
+	partialsKey  = "$partials"
+	constantsKey = "$constants"
+)
+
+// newPartials compiles the partials and constants declared by the bundles of a locale into a set
+// of named templates messages are parsed with, nil if none is declared. Partials are templates
+// sanitized like messages while constants are included verbatim.
+func newPartials(rawPartials, rawConstants map[string]any, options compileOptions) (*template.Template, error) {
+	if len(rawPartials) == 0 && len(rawConstants) == 0 {
+		return nil, nil //nolint:nilnil // No partials is not an error.
+	}
+
+	if options.syntax != SyntaxTemplate {
+		return nil, fmt.Errorf("partials and constants are not available with syntax '%s'", options.syntax)
+	}
+
+	var err error
+	shared := template.New(partialsKey).Delims(leftDelim, rightDelim).Option(executionPolicy).
+		Funcs(options.funcs.parseFuncs())
+	for _, name := range slices.Sorted(maps.Keys(rawPartials)) {
+		raw, isString := rawPartials[name].(string)
+		if !isString {
+			return nil, fmt.Errorf("partial '%s' is not a string", name)
+		}
+
+		if _, err = shared.New(name).Parse(raw); err != nil {
+			return nil, fmt.Errorf("cannot parse partial '%s': %w", name, err)
+		}
+	}
+
+	if options.sanitizer.enabled() {
+		sanitizeTemplate(shared, options.sanitizer, nil)
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(rawConstants)) {
+		if shared.Lookup(name) != nil {
+			return nil, fmt.Errorf("constant '%s' is already declared as partial", name)
+		}
+
+		value := rawConstants[name]
+		switch value.(type) {
+		case map[string]any, []any:
+			return nil, fmt.Errorf("constant '%s' is not a scalar value", name)
+		}
+
+		// Quoted as a string literal so that constants are never interpreted nor sanitized
+		literal := leftDelim + strconv.Quote(fmt.Sprint(value)) + rightDelim
+		if _, err = shared.New(name).Parse(literal); err != nil {
+			return nil, fmt.Errorf("cannot parse constant '%s': %w", name, err)
+		}
+	}
+
+	if _, err = checkPartialCalls(shared, nil); err != nil {
+		return nil, err
+	}
+
+	return shared, nil
+}
+
+// newSharedTemplates merges the partials and constants declared by layers in their loading order,
+// names declared by several layers being resolved with policy like keys.
+func newSharedTemplates(layers []bundleLayer, policy ConflictPolicy) (sharedTemplates, error) {
+	partials, err := mergeNamedValues(partialsKey, layers, policy)
+	if err != nil {
+		return sharedTemplates{}, err
+	}
+
+	constants, err := mergeNamedValues(constantsKey, layers, policy)
+	if err != nil {
+		return sharedTemplates{}, err
+	}
+
+	return sharedTemplates{partials: partials, constants: constants}, nil
+}
+
+func mergeNamedValues(key string, layers []bundleLayer, policy ConflictPolicy) (map[string]any, error) {
+	var merged map[string]any
+	origins := make(map[string]string)
+	for _, layer := range layers {
+		values, isMap := layer.content[key].(map[string]any)
+		if !isMap && layer.content[key] != nil {
+			return nil, fmt.Errorf("'%s' of bundle '%s' is not an object", key, layer.cachePath)
+		}
+
+		for _, name := range slices.Sorted(maps.Keys(values)) {
+			if origin, found := origins[name]; found {
+				switch policy {
+				case ConflictError:
+					return nil, fmt.Errorf("'%s' of bundle '%s' is already declared under '%s' by '%s'",
+						name, layer.cachePath, key, origin)
+				case ConflictFirstWins:
+					continue
+				}
+			}
+
+			if merged == nil {
+				merged = make(map[string]any)
+			}
+			merged[name] = values[name]
+			origins[name] = layer.cachePath
+		}
+	}
+
+	return merged, nil
+}
+
+// checkPartialCalls ensures the templates associated with t, except the ones shared with
+// partials, only include defined templates and do not include each other in a cycle. It
+// reports whether they include any template.
+func checkPartialCalls(t, partials *template.Template) (bool, error) {
+	includes := false
+	calls := make(map[string][]string)
+	for _, associated := range ownTemplates(t, partials) {
+		var names []string
+		walkNodes(associated.Root, func(node parse.Node) {
+			if call, isCall := node.(*parse.TemplateNode); isCall {
+				names = append(names, call.Name)
+			}
+		})
+
+		for _, name := range names {
+			if t.Lookup(name) == nil {
+				return false, fmt.Errorf("template '%s' includes undefined partial '%s'", associated.Name(), name)
+			}
+		}
+		calls[associated.Name()] = names
+		includes = includes || len(names) > 0
+	}
+
+	if cycle := findCycle(calls); cycle != nil {
+		return false, fmt.Errorf("cyclic partials %s", strings.Join(cycle, " -> "))
+	}
+
+	return includes, nil
+}
+
+// reachableTemplates returns t along with the templates associated with it that t includes,
+// directly or through other templates; partials the message does not include are left out.
+func reachableTemplates(t *template.Template) []*template.Template {
+	reached := map[string]bool{t.Name(): true}
+	templates := []*template.Template{t}
+	for i := 0; i < len(templates); i++ {
+		walkNodes(templates[i].Root, func(node parse.Node) {
+			call, isCall := node.(*parse.TemplateNode)
+			if !isCall || reached[call.Name] {
+				return
+			}
+
+			if included := t.Lookup(call.Name); included != nil && included.Tree != nil {
+				reached[call.Name] = true
+				templates = append(templates, included)
+			}
+		})
+	}
+
+	return templates
+}
+
+// ownTemplates returns the templates associated with t which are not shared with partials,
+// i.e. the ones parsed along with a message.
+func ownTemplates(t, partials *template.Template) []*template.Template {
+	var templates []*template.Template
+	for _, associated := range t.Templates() {
+		if associated.Tree == nil {
+			continue
+		}
+
+		if partials != nil {
+			shared := partials.Lookup(associated.Name())
+			if shared != nil && shared.Tree == associated.Tree {
+				continue
+			}
+		}
+		templates = append(templates, associated)
+	}
+
+	return templates
+}
@@ -0,0 +1,197 @@
+package discordgoi18n
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test compiling partials and constants
+func TestNewPartials(t *testing.T) {
+	options := compileOptions{syntax: SyntaxTemplate}
+	partials, err := newPartials(nil, nil, options)
+	assert.NoError(t, err)
+	assert.Nil(t, partials)
+
+	partials, err = newPartials(
+		map[string]any{"footer": "{{ template \"brand\" }} - {{ .name }}", "signature": "{{ template \"footer\" . }}"},
+		map[string]any{"brand": "{{ Gopher }}", "version": 2.5, "beta": true},
+		options)
+	assert.NoError(t, err)
+	for _, name := range []string{"footer", "signature", "brand", "version", "beta"} {
+		assert.NotNil(t, partials.Lookup(name), name)
+	}
+
+	for _, test := range []struct {
+		partials  map[string]any
+		constants map[string]any
+	}{
+		{map[string]any{"footer": 1}, nil},
+		{map[string]any{"footer": "{{ .name"}, nil},
+		{map[string]any{"footer": "{{ template \"missing\" }}"}, nil},
+		{map[string]any{"a": "{{ template \"b\" }}", "b": "{{ template \"a\" }}"}, nil},
+		{map[string]any{"footer": "{{ template \"footer\" }}"}, nil},
+		{map[string]any{"brand": "Gopher"}, map[string]any{"brand": "Gopher"}},
+		{nil, map[string]any{"brand": map[string]any{"name": "Gopher"}}},
+	} {
+		_, err = newPartials(test.partials, test.constants, options)
+		assert.Error(t, err, test)
+	}
+
+	_, err = newPartials(map[string]any{"footer": "Gopher"}, nil, compileOptions{syntax: SyntaxICU})
+	assert.Error(t, err)
+}
+
+// Test merging the partials and constants of the bundles of a locale
+func TestNewSharedTemplates(t *testing.T) {
+	layers := []bundleLayer{
+		{cachePath: "common", content: map[string]any{
+			partialsKey:  map[string]any{"footer": "{{ template \"brand\" }}"},
+			constantsKey: map[string]any{"brand": "Gopher", "support": "https://example.com"},
+		}},
+		{cachePath: "commands", content: map[string]any{constantsKey: map[string]any{"brand": "Gopher Bot"}}},
+		{cachePath: "errors", content: map[string]any{"error": "Error"}},
+	}
+
+	shared, err := newSharedTemplates(layers[:1], ConflictError)
+	assert.NoError(t, err)
+	assert.Equal(t, layers[0].content[partialsKey], shared.partials)
+	assert.Equal(t, layers[0].content[constantsKey], shared.constants)
+
+	shared, err = newSharedTemplates(layers[2:], ConflictError)
+	assert.NoError(t, err)
+	assert.Equal(t, sharedTemplates{}, shared)
+
+	shared, err = newSharedTemplates(layers, ConflictFirstWins)
+	assert.NoError(t, err)
+	assert.Equal(t, "Gopher", shared.constants["brand"])
+	shared, err = newSharedTemplates(layers, ConflictLastWins)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"brand": "Gopher Bot", "support": "https://example.com"}, shared.constants)
+
+	_, err = newSharedTemplates(layers, ConflictError)
+	assert.ErrorContains(t, err, "'brand' of bundle 'commands' is already declared under '$constants' by 'common'")
+
+	for _, content := range []map[string]any{{partialsKey: "footer"}, {constantsKey: []any{"brand"}}} {
+		_, err = newSharedTemplates([]bundleLayer{{cachePath: "bad", content: content}}, ConflictError)
+		assert.ErrorContains(t, err, "of bundle 'bad' is not an object", content)
+	}
+}
+
+// Test rendering messages including partials and constants
+func TestPartials(t *testing.T) {
+	setUp()
+	defer tearDown()
+
+	content := map[string]any{
+		partialsKey: map[string]any{
+			"footer": "{{ template \"brand\" }} by {{ .author }}",
+		},
+		constantsKey: map[string]any{
+			"brand":   "**Gopher** {{ bot }}",
+			"support": "https://example.com/support",
+		},
+		"help":    "Need help? {{ template \"support\" }}",
+		"welcome": "Welcome {{ .name }}! {{ template \"footer\" . }}",
+		"items":   map[string]any{"$plural": "cardinal", "one": "One item, {{ template \"brand\" }}", "other": "{{ .count }} items"},
+		"plain":   "No template here",
+	}
+	assert.NoError(t, translatorTest.LoadBundleContent(discordgo.French, content))
+	assert.Equal(t, "Need help? https://example.com/support", translatorTest.Get(discordgo.French, "help", Vars{}))
+	assert.Equal(t, "Need help? https://example.com/support", translatorTest.Get(discordgo.French, "help", nil))
+	assert.Equal(t, "Welcome Nick! **Gopher** {{ bot }} by Nick",
+		translatorTest.Get(discordgo.French, "welcome", Vars{"name": "Nick", "author": "Nick"}))
+	assert.Equal(t, "One item, **Gopher** {{ bot }}", translatorTest.GetPlural(discordgo.French, "items", 1, nil))
+	assert.Equal(t, "No template here", translatorTest.Get(discordgo.French, "plain", nil))
+	assert.NotContains(t, translatorTest.state.Load().translations[discordgo.French], partialsKey)
+	assert.NotContains(t, translatorTest.state.Load().translations[discordgo.French], "brand")
+
+	// Partials are sanitized once while constants are kept verbatim
+	translatorTest.SetMarkdownEscaping(true)
+	assert.NoError(t, translatorTest.LoadBundleContent(discordgo.French, content))
+	assert.Equal(t, "Welcome \\*Nick\\*! **Gopher** {{ bot }} by \\*Nick\\*",
+		translatorTest.Get(discordgo.French, "welcome", Vars{"name": "*Nick*", "author": "*Nick*"}))
+	assert.Equal(t, "Welcome \\*Nick\\*! **Gopher** {{ bot }} by \\*Nick\\*",
+		translatorTest.Get(discordgo.French, "welcome", Vars{"name": "*Nick*", "author": "*Nick*"}))
+
+	// Invalid partials and messages including unknown ones are rejected at load time
+	for _, invalid := range []map[string]any{
+		{"welcome": "{{ template \"footer\" . }}"},
+		{partialsKey: map[string]any{"footer": "{{ .name }}"}, "welcome": "{{ template \"header\" . }}"},
+		{partialsKey: map[string]any{"footer": "{{ .name }}"}, "footer": "{{ template \"footer\" . }}"},
+		{partialsKey: "footer"},
+	} {
+		assert.Error(t, translatorTest.LoadBundleContent(discordgo.German, invalid), invalid)
+	}
+	assert.NotContains(t, translatorTest.state.Load().translations, discordgo.German)
+
+	// References of partials only count for the messages including them
+	assert.NoError(t, translatorTest.LoadBundleContent(discordgo.Italian, map[string]any{
+		partialsKey: map[string]any{"footer": "{{ t \"common.bot\" . }}"},
+		"common":    map[string]any{"bot": "Bot {{ .name }}"},
+		"hello":     "Hi {{ template \"footer\" . }}",
+	}))
+	assert.Equal(t, "Hi Bot Nick", translatorTest.Get(discordgo.Italian, "hello", Vars{"name": "Nick"}))
+	assert.ErrorContains(t, translatorTest.LoadBundleContent(discordgo.Italian, map[string]any{
+		partialsKey: map[string]any{"footer": "{{ t \"common.bot\" . }}"},
+		"common":    map[string]any{"bot": "Bot {{ template \"footer\" . }}"},
+	}), "cyclic references between keys common.bot -> common.bot")
+
+	// Nested keys are named after their full path, so they do not collide with partials
+	assert.NoError(t, translatorTest.LoadBundleContent(discordgo.German, map[string]any{
+		partialsKey: map[string]any{"footer": "{{ .name }}"},
+		"a":         map[string]any{"footer": "Footer {{ template \"footer\" . }}"},
+	}))
+	assert.Equal(t, "Footer Nick", translatorTest.Get(discordgo.German, "a.footer", Vars{"name": "Nick"}))
+	assert.ErrorContains(t, translatorTest.LoadBundleContent(discordgo.German, map[string]any{
+		"a": map[string]any{"b": map[string]any{"c": "{{ .name"}},
+	}), "key 'a.b.c'")
+}
+
+// Test sharing partials and constants between the merged bundles of a locale
+func TestPartialsMerge(t *testing.T) {
+	setUp()
+	defer tearDown()
+
+	common := map[string]any{
+		constantsKey: map[string]any{"brand": "Gopher"},
+		partialsKey:  map[string]any{"footer": "{{ template \"brand\" }} by {{ .author }}"},
+	}
+	commands := map[string]any{
+		"ping": "Pong! {{ template \"brand\" }}",
+		"help": "Help - {{ template \"footer\" . }}",
+	}
+
+	translatorTest.SetLoadMode(LoadModeMerge)
+	assert.NoError(t, translatorTest.LoadBundleContent(discordgo.French, common))
+	assert.NoError(t, translatorTest.LoadBundleContent(discordgo.French, commands))
+	assert.NoError(t, translatorTest.LoadBundleContent(discordgo.French, map[string]any{
+		syntaxKey: string(SyntaxICU),
+		"count":   "{n, plural, one {# item} other {# items}}",
+	}))
+	assert.Equal(t, "Pong! Gopher", translatorTest.Get(discordgo.French, "ping", nil))
+	assert.Equal(t, "Help - Gopher by Nick", translatorTest.Get(discordgo.French, "help", Vars{"author": "Nick"}))
+	assert.Equal(t, "2 items", translatorTest.Get(discordgo.French, "count", Vars{"n": 2}))
+
+	// Names declared by several bundles are resolved with the conflict policy
+	override := map[string]any{constantsKey: map[string]any{"brand": "Gopher Bot"}}
+	assert.ErrorContains(t, translatorTest.LoadBundleContent(discordgo.French, override),
+		"'brand' of bundle 'content:")
+	translatorTest.SetConflictPolicy(ConflictLastWins)
+	assert.NoError(t, translatorTest.LoadBundleContent(discordgo.French, override))
+	assert.Equal(t, "Pong! Gopher Bot", translatorTest.Get(discordgo.French, "ping", nil))
+
+	// Files of a directory share them whatever their order
+	assert.NoError(t, translatorTest.LoadDirFS(fstest.MapFS{
+		"de/a.json":      {Data: []byte(`{"ping": "Pong! {{ template \"brand\" }}"}`)},
+		"de/common.json": {Data: []byte(`{"$constants": {"brand": "Gopher"}}`)},
+	}, "."))
+	assert.Equal(t, "Pong! Gopher", translatorTest.Get(discordgo.German, "ping", nil))
+
+	// Bundles only include the partials and constants of the bundles they are stored with
+	translatorTest.SetLoadMode(LoadModeReplace)
+	assert.ErrorContains(t, translatorTest.LoadBundleContent(discordgo.French, commands), "includes undefined partial")
+	assert.ErrorContains(t, translatorTest.LoadBundleContent(discordgo.Italian, commands), "includes undefined partial")
+}
@@ -0,0 +1,437 @@
+package discordgoi18n
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// pluralCategory is a CLDR plural category.
+type pluralCategory string
+
+// pluralRule selects the plural category matching the given operands.
+type pluralRule func(operands pluralOperands) pluralCategory
+
+// pluralOperands are the CLDR operands of a number, see
+// https://unicode.org/reports/tr35/tr35-numbers.html#Operands.
+type pluralOperands struct {
+	i int64 // integer digits
+	v int64 // number of visible fraction digits, with trailing zeros
+	w int64 // number of visible fraction digits, without trailing zeros
+	f int64 // visible fraction digits, with trailing zeros
+	t int64 // visible fraction digits, without trailing zeros
+}
+
+const (
+	pluralZero  pluralCategory = "zero"
+	pluralOne   pluralCategory = "one"
+	pluralTwo   pluralCategory = "two"
+	pluralFew   pluralCategory = "few"
+	pluralMany  pluralCategory = "many"
+	pluralOther pluralCategory = "other"
+
+	// pluralKey declares a plural object along with the rules selecting its forms, either
+	// pluralTypeCardinal or pluralTypeOrdinal.
+	pluralKey          = "$plural"
+	pluralTypeCardinal = "cardinal"
+	pluralTypeOrdinal  = "ordinal"
+
+	countVariable = "count"
+	million       = 1000000
+	// pluralSamples is the number of positive integers plural rules are sampled with, zero
+	// being sampled afterwards as gettext and CLDR rules often disagree on it, then a million
+	// for the locales whose many category only applies to millions.
+	pluralSamples = 1000
+)
+
+// newPluralOperands computes the operands of count, which can be any integer, float
+// or numeric string; strings keep their visible fraction digits ("1.0" is not "1").
+func newPluralOperands(count any) (pluralOperands, error) {
+	var raw string
+	switch v := count.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		raw = fmt.Sprintf("%d", v)
+	case float32:
+		raw = strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case float64:
+		raw = strconv.FormatFloat(v, 'f', -1, 64)
+	case string:
+		raw = v
+	default:
+		return pluralOperands{}, fmt.Errorf("count '%v' of type %T is not a number", count, count)
+	}
+
+	return parsePluralOperands(raw)
+}
+
+func parsePluralOperands(raw string) (pluralOperands, error) {
+	integer, fraction, _ := strings.Cut(strings.TrimPrefix(strings.TrimSpace(raw), "-"), ".")
+	i, err := strconv.ParseInt(integer, 10, 64)
+	if err != nil {
+		return pluralOperands{}, fmt.Errorf("count '%s' is not a number: %w", raw, err)
+	}
+
+	operands := pluralOperands{i: i, v: int64(len(fraction))}
+	if fraction == "" {
+		return operands, nil
+	}
+
+	operands.f, err = strconv.ParseInt(fraction, 10, 64)
+	if err != nil || strings.HasPrefix(fraction, "+") {
+		return pluralOperands{}, fmt.Errorf("count '%s' is not a number", raw)
+	}
+
+	trimmed := strings.TrimRight(fraction, "0")
+	operands.w = int64(len(trimmed))
+	if trimmed != "" {
+		operands.t, _ = strconv.ParseInt(trimmed, 10, 64)
+	}
+
+	return operands, nil
+}
+
+// isInteger returns true when n has no fraction value, 1.0 included.
+func (operands pluralOperands) isInteger() bool {
+	return operands.f == 0
+}
+
+// nEquals is the CLDR "n = value" relation.
+func (operands pluralOperands) nEquals(value int64) bool {
+	return operands.isInteger() && operands.i == value
+}
+
+// nModIn is the CLDR "n % mod = from..to" relation.
+func (operands pluralOperands) nModIn(mod, from, to int64) bool {
+	return operands.isInteger() && inRange(operands.i%mod, from, to)
+}
+
+func inRange(value, from, to int64) bool {
+	return value >= from && value <= to
+}
+
+// isPluralContent returns true when content declares its plural type; objects made of plural
+// categories without it are nested keys, such as menu.one and menu.other.
+func isPluralContent(content map[string]any) bool {
+	_, found := content[pluralKey]
+	return found
+}
+
+func isPluralCategory(name string) bool {
+	switch pluralCategory(name) {
+	case pluralZero, pluralOne, pluralTwo, pluralFew, pluralMany, pluralOther:
+		return true
+	}
+
+	return false
+}
+
+// pluralSampleRange returns the integers sampled to find the categories rules use.
+func pluralSampleRange() []int64 {
+	samples := make([]int64, 0, pluralSamples+2)
+	for n := int64(1); n <= pluralSamples; n++ {
+		samples = append(samples, n)
+	}
+
+	return append(samples, 0, million)
+}
+
+// pluralCategories returns the categories rule selects for integers, along with the other
+// category used for decimals.
+func pluralCategories(rule pluralRule) map[pluralCategory]struct{} {
+	categories := map[pluralCategory]struct{}{pluralOther: {}}
+	for _, n := range pluralSampleRange() {
+		operands, err := newPluralOperands(n)
+		if err == nil {
+			categories[rule(operands)] = struct{}{}
+		}
+	}
+
+	return categories
+}
+
+// cardinalRule returns the CLDR cardinal plural rule of locale; unknown locales
+// only use the other category.
+//
+//nolint:exhaustive // Locales without any rule fall back on the root one.
+func cardinalRule(locale discordgo.Locale) pluralRule {
+	switch locale {
+	case discordgo.EnglishUS, discordgo.EnglishGB, discordgo.German, discordgo.Dutch,
+		discordgo.Swedish, discordgo.Finnish:
+		return cardinalOneIntegerOne
+	case discordgo.Italian:
+		return cardinalItalian
+	case discordgo.SpanishES, discordgo.SpanishLATAM:
+		return cardinalSpanish
+	case discordgo.French, discordgo.PortugueseBR:
+		return cardinalFrench
+	case discordgo.Greek, discordgo.Bulgarian, discordgo.Norwegian, discordgo.Hungarian,
+		discordgo.Turkish:
+		return cardinalOneNOne
+	case discordgo.Danish:
+		return cardinalDanish
+	case discordgo.Hindi:
+		return cardinalHindi
+	case discordgo.Croatian:
+		return cardinalCroatian
+	case discordgo.Czech:
+		return cardinalCzech
+	case discordgo.Lithuanian:
+		return cardinalLithuanian
+	case discordgo.Polish:
+		return cardinalPolish
+	case discordgo.Romanian:
+		return cardinalRomanian
+	case discordgo.Russian, discordgo.Ukrainian:
+		return cardinalRussian
+	default:
+		return cardinalOther
+	}
+}
+
+// cardinalOther is used by ja, ko, th, vi, zh-CN and zh-TW.
+func cardinalOther(_ pluralOperands) pluralCategory {
+	return pluralOther
+}
+
+// cardinalOneIntegerOne is used by de, en-GB, en-US, fi, nl and sv-SE.
+func cardinalOneIntegerOne(operands pluralOperands) pluralCategory {
+	if operands.i == 1 && operands.v == 0 {
+		return pluralOne
+	}
+	return pluralOther
+}
+
+// cardinalOneNOne is used by bg, el, hu, no and tr.
+func cardinalOneNOne(operands pluralOperands) pluralCategory {
+	if operands.nEquals(1) {
+		return pluralOne
+	}
+	return pluralOther
+}
+
+// isMillionMultiple is the many category shared by Romance languages ("1 million de").
+func isMillionMultiple(operands pluralOperands) bool {
+	return operands.i != 0 && operands.i%million == 0 && operands.v == 0
+}
+
+func cardinalItalian(operands pluralOperands) pluralCategory {
+	switch {
+	case operands.i == 1 && operands.v == 0:
+		return pluralOne
+	case isMillionMultiple(operands):
+		return pluralMany
+	default:
+		return pluralOther
+	}
+}
+
+func cardinalSpanish(operands pluralOperands) pluralCategory {
+	switch {
+	case operands.nEquals(1):
+		return pluralOne
+	case isMillionMultiple(operands):
+		return pluralMany
+	default:
+		return pluralOther
+	}
+}
+
+// cardinalFrench is used by fr and pt-BR.
+func cardinalFrench(operands pluralOperands) pluralCategory {
+	switch {
+	case inRange(operands.i, 0, 1):
+		return pluralOne
+	case isMillionMultiple(operands):
+		return pluralMany
+	default:
+		return pluralOther
+	}
+}
+
+func cardinalDanish(operands pluralOperands) pluralCategory {
+	if operands.nEquals(1) || operands.t != 0 && inRange(operands.i, 0, 1) {
+		return pluralOne
+	}
+	return pluralOther
+}
+
+func cardinalHindi(operands pluralOperands) pluralCategory {
+	if operands.i == 0 || operands.nEquals(1) {
+		return pluralOne
+	}
+	return pluralOther
+}
+
+func cardinalCroatian(operands pluralOperands) pluralCategory {
+	switch {
+	case operands.v == 0 && operands.i%10 == 1 && operands.i%100 != 11,
+		operands.f%10 == 1 && operands.f%100 != 11:
+		return pluralOne
+	case operands.v == 0 && inRange(operands.i%10, 2, 4) && !inRange(operands.i%100, 12, 14),
+		inRange(operands.f%10, 2, 4) && !inRange(operands.f%100, 12, 14):
+		return pluralFew
+	default:
+		return pluralOther
+	}
+}
+
+func cardinalCzech(operands pluralOperands) pluralCategory {
+	switch {
+	case operands.i == 1 && operands.v == 0:
+		return pluralOne
+	case inRange(operands.i, 2, 4) && operands.v == 0:
+		return pluralFew
+	case operands.v != 0:
+		return pluralMany
+	default:
+		return pluralOther
+	}
+}
+
+func cardinalLithuanian(operands pluralOperands) pluralCategory {
+	switch {
+	case operands.nModIn(10, 1, 1) && !operands.nModIn(100, 11, 19):
+		return pluralOne
+	case operands.nModIn(10, 2, 9) && !operands.nModIn(100, 11, 19):
+		return pluralFew
+	case operands.f != 0:
+		return pluralMany
+	default:
+		return pluralOther
+	}
+}
+
+func cardinalPolish(operands pluralOperands) pluralCategory {
+	switch {
+	case operands.i == 1 && operands.v == 0:
+		return pluralOne
+	case operands.v == 0 && inRange(operands.i%10, 2, 4) && !inRange(operands.i%100, 12, 14):
+		return pluralFew
+	case operands.v == 0 && operands.i != 1 && inRange(operands.i%10, 0, 1),
+		operands.v == 0 && inRange(operands.i%10, 5, 9),
+		operands.v == 0 && inRange(operands.i%100, 12, 14):
+		return pluralMany
+	default:
+		return pluralOther
+	}
+}
+
+func cardinalRomanian(operands pluralOperands) pluralCategory {
+	switch {
+	case operands.i == 1 && operands.v == 0:
+		return pluralOne
+	case operands.v != 0 || operands.nEquals(0) || operands.nModIn(100, 1, 19):
+		return pluralFew
+	default:
+		return pluralOther
+	}
+}
+
+// cardinalRussian is used by ru and uk.
+func cardinalRussian(operands pluralOperands) pluralCategory {
+	switch {
+	case operands.v == 0 && operands.i%10 == 1 && operands.i%100 != 11:
+		return pluralOne
+	case operands.v == 0 && inRange(operands.i%10, 2, 4) && !inRange(operands.i%100, 12, 14):
+		return pluralFew
+	case operands.v == 0 && operands.i%10 == 0,
+		operands.v == 0 && inRange(operands.i%10, 5, 9),
+		operands.v == 0 && inRange(operands.i%100, 11, 14):
+		return pluralMany
+	default:
+		return pluralOther
+	}
+}
+
+// ordinalRule returns the CLDR ordinal plural rule of locale; unknown locales
+// only use the other category.
+//
+//nolint:exhaustive // Locales without any rule fall back on the root one.
+func ordinalRule(locale discordgo.Locale) pluralRule {
+	switch locale {
+	case discordgo.EnglishUS, discordgo.EnglishGB:
+		return ordinalEnglish
+	case discordgo.French, discordgo.Romanian, discordgo.Vietnamese:
+		return ordinalOneNOne
+	case discordgo.Hungarian:
+		return ordinalHungarian
+	case discordgo.Italian:
+		return ordinalItalian
+	case discordgo.Swedish:
+		return ordinalSwedish
+	case discordgo.Hindi:
+		return ordinalHindi
+	case discordgo.Ukrainian:
+		return ordinalUkrainian
+	default:
+		// bg, cs, da, de, el, es-ES, es-419, fi, hr, ja, ko, lt, nl, no, pl, pt-BR, ru, th, tr, zh-CN, zh-TW
+		return cardinalOther
+	}
+}
+
+// ordinalEnglish is used by en-GB and en-US.
+func ordinalEnglish(operands pluralOperands) pluralCategory {
+	switch {
+	case operands.nModIn(10, 1, 1) && !operands.nModIn(100, 11, 11):
+		return pluralOne
+	case operands.nModIn(10, 2, 2) && !operands.nModIn(100, 12, 12):
+		return pluralTwo
+	case operands.nModIn(10, 3, 3) && !operands.nModIn(100, 13, 13):
+		return pluralFew
+	default:
+		return pluralOther
+	}
+}
+
+// ordinalOneNOne is used by fr, ro and vi.
+func ordinalOneNOne(operands pluralOperands) pluralCategory {
+	if operands.nEquals(1) {
+		return pluralOne
+	}
+	return pluralOther
+}
+
+func ordinalHungarian(operands pluralOperands) pluralCategory {
+	if operands.nEquals(1) || operands.nEquals(5) {
+		return pluralOne
+	}
+	return pluralOther
+}
+
+func ordinalItalian(operands pluralOperands) pluralCategory {
+	if operands.nEquals(11) || operands.nEquals(8) || operands.nEquals(80) || operands.nEquals(800) {
+		return pluralMany
+	}
+	return pluralOther
+}
+
+func ordinalSwedish(operands pluralOperands) pluralCategory {
+	if operands.nModIn(10, 1, 2) && !operands.nModIn(100, 11, 12) {
+		return pluralOne
+	}
+	return pluralOther
+}
+
+func ordinalHindi(operands pluralOperands) pluralCategory {
+	switch {
+	case operands.nEquals(1):
+		return pluralOne
+	case operands.nEquals(2) || operands.nEquals(3):
+		return pluralTwo
+	case operands.nEquals(4):
+		return pluralFew
+	case operands.nEquals(6):
+		return pluralMany
+	default:
+		return pluralOther
+	}
+}
+
+func ordinalUkrainian(operands pluralOperands) pluralCategory {
+	if operands.nModIn(10, 3, 3) && !operands.nModIn(100, 13, 13) {
+		return pluralFew
+	}
+	return pluralOther
+}
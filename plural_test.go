@@ -0,0 +1,154 @@
+package discordgoi18n
+
+import (
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test computing plural operands
+func TestNewPluralOperands(t *testing.T) {
+	for count, expected := range map[any]pluralOperands{
+		0:             {},
+		5:             {i: 5},
+		int64(-3):     {i: 3},
+		uint8(21):     {i: 21},
+		1.5:           {i: 1, v: 1, w: 1, f: 5, t: 5},
+		float32(2.25): {i: 2, v: 2, w: 2, f: 25, t: 25},
+		"1.0":         {i: 1, v: 1},
+		"1.50":        {i: 1, v: 2, w: 1, f: 50, t: 5},
+		"-10.05":      {i: 10, v: 2, w: 2, f: 5, t: 5},
+	} {
+		operands, err := newPluralOperands(count)
+		assert.NoError(t, err, count)
+		assert.Equal(t, expected, operands, count)
+	}
+
+	for _, count := range []any{nil, "abc", "1.a", "1.+5", "", true, []int{1}} {
+		_, err := newPluralOperands(count)
+		assert.Error(t, err, count)
+	}
+}
+
+// Test every Discord locale has a cardinal rule returning valid categories
+func TestCardinalRuleLocales(t *testing.T) {
+	for locale := range discordgo.Locales {
+		rule := cardinalRule(locale)
+		assert.NotNil(t, rule, locale)
+		for _, count := range []any{0, 1, 2, 3, 5, 11, 21, 22, 25, 100, 1000000, 0.5, "1.0", 1.5} {
+			operands, err := newPluralOperands(count)
+			assert.NoError(t, err)
+			assert.Contains(t, []pluralCategory{pluralZero, pluralOne, pluralTwo, pluralFew, pluralMany, pluralOther},
+				rule(operands), locale)
+		}
+	}
+}
+
+// Test cardinal rules against CLDR samples
+func TestCardinalRule(t *testing.T) {
+	samples := map[discordgo.Locale]map[pluralCategory][]any{
+		discordgo.EnglishUS:    {pluralOne: {1}, pluralOther: {0, 2, 11, "1.0", 1.5}},
+		discordgo.French:       {pluralOne: {0, 1, 1.5}, pluralMany: {1000000, 2000000}, pluralOther: {2, 100, 1000001}},
+		discordgo.PortugueseBR: {pluralOne: {0, 1, 1.5}, pluralMany: {1000000}, pluralOther: {2, 10}},
+		discordgo.SpanishES:    {pluralOne: {1, "1.0"}, pluralMany: {1000000}, pluralOther: {0, 2, 1.5}},
+		discordgo.Italian:      {pluralOne: {1}, pluralMany: {3000000}, pluralOther: {0, 2, "1.0"}},
+		discordgo.Danish:       {pluralOne: {1, "1.0", 0.5, 1.5}, pluralOther: {0, 2, 2.5}},
+		discordgo.Hindi:        {pluralOne: {0, 1, 0.5}, pluralOther: {2, 1.5}},
+		discordgo.Greek:        {pluralOne: {1}, pluralOther: {0, 2, 1.5}},
+		discordgo.Japanese:     {pluralOther: {0, 1, 2, 1.5}},
+		discordgo.ChineseTW:    {pluralOther: {1}},
+		discordgo.Czech:        {pluralOne: {1}, pluralFew: {2, 3, 4}, pluralMany: {1.5, "1.0"}, pluralOther: {0, 5, 22}},
+		discordgo.Polish: {
+			pluralOne: {1}, pluralFew: {2, 3, 4, 22, 104}, pluralMany: {0, 5, 11, 12, 14, 21, 25, 111},
+			pluralOther: {1.5, "1.0"},
+		},
+		discordgo.Russian: {
+			pluralOne: {1, 21, 101}, pluralFew: {2, 4, 22, 33}, pluralMany: {0, 5, 11, 12, 14, 25, 111},
+			pluralOther: {1.5, "2.0"},
+		},
+		discordgo.Ukrainian:  {pluralOne: {21}, pluralFew: {3}, pluralMany: {11}, pluralOther: {0.1}},
+		discordgo.Croatian:   {pluralOne: {1, 21, 0.1, 2.1}, pluralFew: {2, 24, 0.2, 1.4}, pluralOther: {0, 5, 11, 12, 0.5}},
+		discordgo.Lithuanian: {pluralOne: {1, 21, 31}, pluralFew: {2, 9, 22}, pluralMany: {0.1, 1.5}, pluralOther: {0, 10, 11, 19}},
+		discordgo.Romanian:   {pluralOne: {1}, pluralFew: {0, 2, 19, 101, 119, 1.5}, pluralOther: {20, 100, 120}},
+		"xx":                 {pluralOther: {0, 1, 2}},
+	}
+
+	for locale, categories := range samples {
+		rule := cardinalRule(locale)
+		for category, counts := range categories {
+			for _, count := range counts {
+				operands, err := newPluralOperands(count)
+				assert.NoError(t, err)
+				assert.Equal(t, category, rule(operands), "%s: %v", locale, count)
+			}
+		}
+	}
+}
+
+// Test detecting plural objects
+func TestIsPluralContent(t *testing.T) {
+	assert.True(t, isPluralContent(map[string]any{"$plural": "cardinal", "one": "coin", "other": "coins"}))
+	assert.True(t, isPluralContent(map[string]any{"$plural": "ordinal", "one": "st", "other": "th"}))
+	assert.True(t, isPluralContent(map[string]any{"$plural": "cardinal"}))
+
+	// Objects made of plural categories without declaring it are nested keys
+	assert.False(t, isPluralContent(map[string]any{"one": "Option one", "other": "Other"}))
+	assert.False(t, isPluralContent(map[string]any{}))
+}
+
+// Test rejecting invalid plural objects
+func TestNewPluralEntry(t *testing.T) {
+	options := compileOptions{syntax: SyntaxTemplate}
+	for expected, content := range map[string]map[string]any{
+		"does not declare the 'other' form":     {"$plural": "cardinal", "one": "coin"},
+		"'name' of key 'coins' is not a CLDR":   {"$plural": "cardinal", "name": "coin", "other": "coins"},
+		"is neither 'cardinal' nor 'ordinal'":   {"$plural": "ordinals", "other": "coins"},
+		"template: coins:1: function \"x\" not": {"$plural": "cardinal", "other": "{{ x }}"},
+	} {
+		_, err := newPluralEntry("coins", content, options)
+		assert.ErrorContains(t, err, expected)
+	}
+
+	pluralEntry, err := newPluralEntry("rank", map[string]any{"$plural": "ordinal", "one": "st", "other": "th"}, options)
+	assert.NoError(t, err)
+	assert.True(t, pluralEntry.ordinal)
+	assert.Len(t, pluralEntry.plurals, 2)
+}
+
+// Test ordinal rules against CLDR samples
+func TestOrdinalRule(t *testing.T) {
+	samples := map[discordgo.Locale]map[pluralCategory][]any{
+		discordgo.EnglishUS: {
+			pluralOne: {1, 21, 101}, pluralTwo: {2, 22, 102}, pluralFew: {3, 23, 103},
+			pluralOther: {0, 4, 11, 12, 13, 111, 112, 113},
+		},
+		discordgo.EnglishGB:  {pluralOne: {31}, pluralOther: {11}},
+		discordgo.French:     {pluralOne: {1}, pluralOther: {0, 2, 21}},
+		discordgo.Romanian:   {pluralOne: {1}, pluralOther: {2}},
+		discordgo.Vietnamese: {pluralOne: {1}, pluralOther: {2}},
+		discordgo.Hungarian:  {pluralOne: {1, 5}, pluralOther: {0, 2, 15}},
+		discordgo.Italian:    {pluralMany: {8, 11, 80, 800}, pluralOther: {0, 1, 18, 81}},
+		discordgo.Swedish:    {pluralOne: {1, 2, 21, 22, 102}, pluralOther: {0, 3, 11, 12, 111}},
+		discordgo.Hindi:      {pluralOne: {1}, pluralTwo: {2, 3}, pluralFew: {4}, pluralMany: {6}, pluralOther: {0, 5, 7}},
+		discordgo.Ukrainian:  {pluralFew: {3, 23, 33}, pluralOther: {0, 1, 13, 113}},
+		discordgo.Russian:    {pluralOther: {1, 2, 3}},
+		discordgo.SpanishES:  {pluralOther: {1, 2, 3}},
+		discordgo.German:     {pluralOther: {1, 2, 3}},
+	}
+
+	for locale, categories := range samples {
+		rule := ordinalRule(locale)
+		for category, counts := range categories {
+			for _, count := range counts {
+				operands, err := newPluralOperands(count)
+				assert.NoError(t, err)
+				assert.Equal(t, category, rule(operands), "%s: %v", locale, count)
+			}
+		}
+	}
+
+	for locale := range discordgo.Locales {
+		assert.NotNil(t, ordinalRule(locale), locale)
+	}
+}
@@ -0,0 +1,257 @@
+package discordgoi18n
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// pluralFormula computes the index of the gettext plural form used for n.
+type pluralFormula func(n int64) int64
+
+// pluralFormsParser parses the C expressions of gettext Plural-Forms headers, made of n,
+// integers, parentheses, arithmetic, comparison, logical and ternary operators.
+type pluralFormsParser struct {
+	raw      string
+	position int
+}
+
+// parsePluralForms parses a Plural-Forms header such as "nplurals=2; plural=(n != 1);".
+func parsePluralForms(header string) (int, pluralFormula, error) {
+	var (
+		count   int
+		formula pluralFormula
+	)
+
+	for _, field := range strings.Split(header, ";") {
+		name, value, found := strings.Cut(field, "=")
+		if !found {
+			continue
+		}
+
+		var err error
+		switch strings.TrimSpace(name) {
+		case "nplurals":
+			count, err = strconv.Atoi(strings.TrimSpace(value))
+			if err == nil && count < 1 {
+				err = fmt.Errorf("%d plural forms", count)
+			}
+		case "plural":
+			formula, err = parsePluralFormula(value)
+		}
+		if err != nil {
+			return 0, nil, fmt.Errorf("invalid Plural-Forms '%s': %w", header, err)
+		}
+	}
+
+	if count == 0 || formula == nil {
+		return 0, nil, fmt.Errorf("invalid Plural-Forms '%s': nplurals and plural are expected", header)
+	}
+
+	return count, formula, nil
+}
+
+func parsePluralFormula(raw string) (pluralFormula, error) {
+	parser := &pluralFormsParser{raw: raw}
+	formula, err := parser.parseTernary()
+	if err != nil {
+		return nil, err
+	}
+
+	parser.skipSpaces()
+	if parser.position < len(parser.raw) {
+		return nil, fmt.Errorf("unexpected '%s' at position %d", parser.raw[parser.position:], parser.position)
+	}
+
+	return formula, nil
+}
+
+func (parser *pluralFormsParser) parseTernary() (pluralFormula, error) {
+	condition, err := parser.parseBinary(0)
+	if err != nil || !parser.consume("?") {
+		return condition, err
+	}
+
+	then, err := parser.parseTernary()
+	if err != nil {
+		return nil, err
+	}
+
+	if !parser.consume(":") {
+		return nil, fmt.Errorf("':' expected at position %d", parser.position)
+	}
+
+	otherwise, err := parser.parseTernary()
+	if err != nil {
+		return nil, err
+	}
+
+	return func(n int64) int64 {
+		if condition(n) != 0 {
+			return then(n)
+		}
+		return otherwise(n)
+	}, nil
+}
+
+// pluralOperators lists binary operators from the lowest precedence to the highest one,
+// longer operators first within a level so that <= is not read as <.
+func pluralOperators() [][]string {
+	return [][]string{
+		{"||"},
+		{"&&"},
+		{"==", "!="},
+		{"<=", ">=", "<", ">"},
+		{"+", "-"},
+		{"*", "/", "%"},
+	}
+}
+
+func (parser *pluralFormsParser) parseBinary(level int) (pluralFormula, error) {
+	operators := pluralOperators()
+	if level == len(operators) {
+		return parser.parseUnary()
+	}
+
+	left, err := parser.parseBinary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		operator := ""
+		for _, candidate := range operators[level] {
+			if parser.consume(candidate) {
+				operator = candidate
+				break
+			}
+		}
+		if operator == "" {
+			return left, nil
+		}
+
+		right, err := parser.parseBinary(level + 1)
+		if err != nil {
+			return nil, err
+		}
+		left = binaryFormula(operator, left, right)
+	}
+}
+
+func binaryFormula(operator string, left, right pluralFormula) pluralFormula {
+	return func(n int64) int64 {
+		l, r := left(n), right(n)
+		switch operator {
+		case "||":
+			return boolFormula(l != 0 || r != 0)
+		case "&&":
+			return boolFormula(l != 0 && r != 0)
+		case "==":
+			return boolFormula(l == r)
+		case "!=":
+			return boolFormula(l != r)
+		case "<=":
+			return boolFormula(l <= r)
+		case ">=":
+			return boolFormula(l >= r)
+		case "<":
+			return boolFormula(l < r)
+		case ">":
+			return boolFormula(l > r)
+		case "+":
+			return l + r
+		case "-":
+			return l - r
+		case "*":
+			return l * r
+		case "/", "%":
+			// Plural formulas never divide by zero, avoid panicking on invalid ones
+			if r == 0 {
+				return 0
+			}
+			if operator == "/" {
+				return l / r
+			}
+			return l % r
+		default:
+			return 0
+		}
+	}
+}
+
+func boolFormula(value bool) int64 {
+	if value {
+		return 1
+	}
+	return 0
+}
+
+func (parser *pluralFormsParser) parseUnary() (pluralFormula, error) {
+	switch {
+	case parser.consume("!"):
+		operand, err := parser.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return func(n int64) int64 { return boolFormula(operand(n) == 0) }, nil
+	case parser.consume("-"):
+		operand, err := parser.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return func(n int64) int64 { return -operand(n) }, nil
+	case parser.consume("("):
+		formula, err := parser.parseTernary()
+		if err != nil {
+			return nil, err
+		}
+		if !parser.consume(")") {
+			return nil, fmt.Errorf("')' expected at position %d", parser.position)
+		}
+		return formula, nil
+	case parser.consume("n"):
+		return func(n int64) int64 { return n }, nil
+	}
+
+	start := parser.position
+	for parser.position < len(parser.raw) && unicode.IsDigit(rune(parser.raw[parser.position])) {
+		parser.position++
+	}
+	if start == parser.position {
+		if parser.position == len(parser.raw) {
+			return nil, errors.New("unexpected end of formula")
+		}
+		return nil, fmt.Errorf("unexpected '%c' at position %d", parser.raw[parser.position], parser.position)
+	}
+
+	value, err := strconv.ParseInt(parser.raw[start:parser.position], 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	return func(int64) int64 { return value }, nil
+}
+
+// consume skips spaces then token if raw continues with it.
+func (parser *pluralFormsParser) consume(token string) bool {
+	parser.skipSpaces()
+	if !strings.HasPrefix(parser.raw[parser.position:], token) {
+		return false
+	}
+
+	// Negation must not consume the first character of !=
+	next := parser.position + len(token)
+	if token == "!" && strings.HasPrefix(parser.raw[next:], "=") {
+		return false
+	}
+
+	parser.position = next
+	return true
+}
+
+func (parser *pluralFormsParser) skipSpaces() {
+	for parser.position < len(parser.raw) && unicode.IsSpace(rune(parser.raw[parser.position])) {
+		parser.position++
+	}
+}
@@ -0,0 +1,50 @@
+package discordgoi18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test evaluating the plural formulas of gettext Plural-Forms headers
+func TestParsePluralForms(t *testing.T) {
+	for header, expected := range map[string]map[int64]int64{
+		"nplurals=2; plural=(n != 1);":       {0: 1, 1: 0, 2: 1, 11: 1},
+		"nplurals=2; plural=(n > 1);":        {0: 0, 1: 0, 2: 1},
+		"nplurals=1; plural=0;":              {0: 0, 1: 0, 5: 0},
+		"nplurals=2; plural=n>1":             {1: 0, 2: 1},
+		" nplurals = 3 ; plural = n%3 ; ":    {4: 1, 5: 2, 6: 0},
+		"nplurals=2; plural=!(n == 1);":      {1: 0, 2: 1},
+		"nplurals=2; plural=-n < -1 ? 1 : 0": {1: 0, 2: 1},
+		"nplurals=3; plural=(n%10==1 && n%100!=11 ? 0 : n%10>=2 && n%10<=4 && (n%100<10 || n%100>=20) ? 1 : 2);": {
+			1: 0, 2: 1, 4: 1, 5: 2, 11: 2, 12: 2, 21: 0, 22: 1, 25: 2, 111: 2,
+		},
+		"nplurals=3; plural=(n==1 ? 0 : n%10>=2 && n%10<=4 && (n%100<12 || n%100>14) ? 1 : 2);": {
+			1: 0, 2: 1, 5: 2, 12: 2, 22: 1, 0: 2,
+		},
+		"nplurals=2; plural=n/0;": {5: 0},
+	} {
+		count, formula, err := parsePluralForms(header)
+		if assert.NoError(t, err, header) {
+			assert.Positive(t, count, header)
+			for n, index := range expected {
+				assert.Equal(t, index, formula(n), "%s with %d", header, n)
+			}
+		}
+	}
+
+	for _, header := range []string{
+		"",
+		"plural=(n != 1);",
+		"nplurals=0; plural=0;",
+		"nplurals=two; plural=(n != 1);",
+		"nplurals=2;",
+		"nplurals=2; plural=(n != 1;",
+		"nplurals=2; plural=n ? 1;",
+		"nplurals=2; plural=x;",
+		"nplurals=2; plural=n 1;",
+	} {
+		_, _, err := parsePluralForms(header)
+		assert.Error(t, err, header)
+	}
+}
@@ -0,0 +1,209 @@
+package discordgoi18n
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"text/template/parse"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	// referenceFuncName is the template function rendering another key of the bundle.
+	referenceFuncName = "t"
+	// maxReferenceDepth bounds nested translations, which could loop through fallbacks.
+	maxReferenceDepth = 8
+)
+
+// referenceRenderer renders key in locale with variables as nested translation at depth.
+type referenceRenderer func(locale discordgo.Locale, key string, variables Vars, depth int) (string, error)
+
+// templateKey identifies a template clone bound to a rendering locale and nesting depth.
+type templateKey struct {
+	locale discordgo.Locale
+	depth  int
+}
+
+// referenceFunc returns the t template function, rendering keys in locale at depth+1 with
+// renderer. Its optional argument is the variables of the nested translation, usually dot.
+func referenceFunc(renderer referenceRenderer, locale discordgo.Locale,
+	depth int) func(key string, data ...any) (Markdown, error) {
+	return func(key string, data ...any) (Markdown, error) {
+		if depth >= maxReferenceDepth {
+			return "", fmt.Errorf("key '%s' exceeds %d nested translations, references may be cyclic",
+				key, maxReferenceDepth)
+		}
+
+		variables, err := referenceVariables(data)
+		if err != nil {
+			return "", err
+		}
+
+		// Nested translations are sanitized on their own
+		translation, err := renderer(locale, key, variables, depth+1)
+		return Markdown(translation), err
+	}
+}
+
+// unboundReference is the t template function of messages compiled without translator.
+func unboundReference(_ string, _ ...any) (Markdown, error) {
+	return "", errors.New("nested translations are only available through a translator")
+}
+
+func referenceVariables(data []any) (Vars, error) {
+	switch len(data) {
+	case 0:
+		return Vars{}, nil
+	case 1:
+		switch v := data[0].(type) {
+		case Vars:
+			return v, nil
+		case map[string]any:
+			return v, nil
+		default:
+			return nil, fmt.Errorf("variables '%v' of type %T are not Vars", data[0], data[0])
+		}
+	default:
+		return nil, fmt.Errorf("at most one variables argument expected, got %d", len(data))
+	}
+}
+
+// checkReferences rejects bundles in which keys reference each other in a cycle, considering
+// the keys written as string literals in t calls. Keys missing from the bundle are resolved
+// through fallbacks when rendered, and bounded by maxReferenceDepth.
+func (bundle bundle) checkReferences() error {
+	references := make(map[string][]string, len(bundle))
+	for key, entry := range bundle {
+		references[key] = entry.references()
+	}
+
+	if cycle := findCycle(references); cycle != nil {
+		return fmt.Errorf("cyclic references between keys %s", strings.Join(cycle, " -> "))
+	}
+
+	return nil
+}
+
+// findCycle returns the first cycle found in graph as a path starting and ending with the
+// same node, nil if graph is acyclic. Edges to nodes missing from graph are ignored.
+func findCycle(graph map[string][]string) []string {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	states := make(map[string]int, len(graph))
+	var visit func(node string, path []string) []string
+	visit = func(node string, path []string) []string {
+		switch states[node] {
+		case visiting:
+			return append(path, node)
+		case visited:
+			return nil
+		}
+
+		edges, found := graph[node]
+		if !found {
+			return nil
+		}
+
+		states[node] = visiting
+		for _, edge := range edges {
+			if cycle := visit(edge, append(path, node)); cycle != nil {
+				return cycle
+			}
+		}
+		states[node] = visited
+
+		return nil
+	}
+
+	for _, node := range slices.Sorted(maps.Keys(graph)) {
+		if cycle := visit(node, nil); cycle != nil {
+			return cycle
+		}
+	}
+
+	return nil
+}
+
+// references returns the keys referenced by the messages of the entry.
+func (entry *entry) references() []string {
+	var references []string
+	for _, message := range entry.allMessages() {
+		references = append(references, message.referencedKeys()...)
+	}
+
+	return references
+}
+
+// referencedKeys returns the keys written as string literals in the t calls of the message,
+// including the ones of the partials it includes.
+func (message *message) referencedKeys() []string {
+	if message.template == nil {
+		return nil
+	}
+
+	var references []string
+	for _, associated := range reachableTemplates(message.template) {
+		walkNodes(associated.Root, func(node parse.Node) {
+			command, isCommand := node.(*parse.CommandNode)
+			if !isCommand || len(command.Args) < 2 {
+				return
+			}
+
+			identifier, isIdentifier := command.Args[0].(*parse.IdentifierNode)
+			key, isString := command.Args[1].(*parse.StringNode)
+			if isIdentifier && isString && identifier.Ident == referenceFuncName {
+				references = append(references, key.Text)
+			}
+		})
+	}
+
+	return references
+}
+
+// walkNodes calls visit with node and every node it contains.
+func walkNodes(node parse.Node, visit func(node parse.Node)) {
+	visit(node)
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			walkNodes(child, visit)
+		}
+	case *parse.ActionNode:
+		walkNodes(n.Pipe, visit)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, command := range n.Cmds {
+			walkNodes(command, visit)
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			walkNodes(arg, visit)
+		}
+	case *parse.TemplateNode:
+		walkNodes(n.Pipe, visit)
+	case *parse.IfNode:
+		walkBranch(&n.BranchNode, visit)
+	case *parse.RangeNode:
+		walkBranch(&n.BranchNode, visit)
+	case *parse.WithNode:
+		walkBranch(&n.BranchNode, visit)
+	}
+}
+
+func walkBranch(branch *parse.BranchNode, visit func(node parse.Node)) {
+	walkNodes(branch.Pipe, visit)
+	walkNodes(branch.List, visit)
+	walkNodes(branch.ElseList, visit)
+}
@@ -0,0 +1,79 @@
+package discordgoi18n
+
+import (
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test detecting cyclic references when compiling bundles
+func TestCheckReferences(t *testing.T) {
+	setUp()
+	defer tearDown()
+
+	for _, content := range []map[string]any{
+		{"self": `{{ t "self" }}`},
+		{"a": `{{ t "b" }}`, "b": `{{ if .ok }}{{ t "a" . }}{{ end }}`},
+		{"a": []any{"fine", `{{ t "b" }}`}, "b": map[string]any{"$plural": "cardinal", "one": "{{ t \"c\" }}", "other": "b"}, "c": `{{ t "a" }}`},
+		{"nested": map[string]any{"key": `{{ t "nested.key" }}`}},
+	} {
+		_, err := translatorTest.compileBundle(translatorTest.state.Load(), SyntaxTemplate, content, sharedTemplates{})
+		assert.Error(t, err, content)
+	}
+
+	for _, content := range []map[string]any{
+		{"a": `{{ t "b" }} {{ t "b" }}`, "b": `{{ t "c" }}`, "c": "c"},
+		{"a": `{{ t "missing" }}`},
+		{"a": `{{ t .key }}`},
+		{"a": `{{ "t" }} {{ print "a" }}`},
+	} {
+		_, err := translatorTest.compileBundle(translatorTest.state.Load(), SyntaxTemplate, content, sharedTemplates{})
+		assert.NoError(t, err, content)
+	}
+}
+
+// Test the variables given to nested translations
+func TestReferenceVariables(t *testing.T) {
+	variables, err := referenceVariables(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, Vars{}, variables)
+
+	variables, err = referenceVariables([]any{Vars{"name": "Nick"}})
+	assert.NoError(t, err)
+	assert.Equal(t, Vars{"name": "Nick"}, variables)
+
+	variables, err = referenceVariables([]any{map[string]any{"name": "Nick"}})
+	assert.NoError(t, err)
+	assert.Equal(t, Vars{"name": "Nick"}, variables)
+
+	_, err = referenceVariables([]any{"Nick"})
+	assert.Error(t, err)
+
+	_, err = referenceVariables([]any{Vars{}, Vars{}})
+	assert.Error(t, err)
+}
+
+// Test the t function depth limit and unbound usage
+func TestReferenceFunc(t *testing.T) {
+	var depths []int
+	renderer := func(_ discordgo.Locale, key string, _ Vars, depth int) (string, error) {
+		depths = append(depths, depth)
+		return "**" + key + "**", nil
+	}
+
+	translation, err := referenceFunc(renderer, discordgo.French, 0)("key")
+	assert.NoError(t, err)
+	assert.Equal(t, Markdown("**key**"), translation)
+	assert.Equal(t, []int{1}, depths)
+
+	_, err = referenceFunc(renderer, discordgo.French, maxReferenceDepth)("key")
+	assert.Error(t, err)
+	assert.Equal(t, []int{1}, depths)
+
+	_, err = referenceFunc(renderer, discordgo.French, 0)("key", "Nick")
+	assert.Error(t, err)
+
+	_, err = unboundReference("key")
+	assert.Error(t, err)
+}
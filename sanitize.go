@@ -0,0 +1,157 @@
+package discordgoi18n
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	// sanitizeFuncName is the template function appended to every printing action when
+	// variables are sanitized, called with the sanitizer settings.
+	sanitizeFuncName = "sanitize"
+
+	// zeroWidthSpace is inserted in mentions to prevent Discord from parsing them.
+	zeroWidthSpace  = "\u200b"
+	mentionEveryone = "@everyone"
+	mentionHere     = "@here"
+	mentionPrefix   = "<@"
+	mentionSuffix   = '>'
+	nicknamePrefix  = "!"
+	rolePrefix      = "&"
+)
+
+// sanitizer describes how variables injected in messages are sanitized.
+type sanitizer struct {
+	escapeMarkdown     bool
+	neutralizeMentions bool
+}
+
+func (sanitizer sanitizer) enabled() bool {
+	return sanitizer.escapeMarkdown || sanitizer.neutralizeMentions
+}
+
+// sanitize formats value and sanitizes it, unless value is Markdown.
+func (sanitizer sanitizer) sanitize(value any) Markdown {
+	if markdown, isMarkdown := value.(Markdown); isMarkdown {
+		return markdown
+	}
+
+	text := fmt.Sprint(value)
+	if sanitizer.escapeMarkdown {
+		text = escapeMarkdownText(text)
+	}
+	if sanitizer.neutralizeMentions {
+		text = neutralizeMentionsText(text)
+	}
+
+	return Markdown(text)
+}
+
+// neutralizeMentionsText prevents @everyone, @here, user and role mentions from pinging anyone
+// while keeping them readable.
+func neutralizeMentionsText(text string) string {
+	if !strings.Contains(text, "@") {
+		return text
+	}
+
+	return strings.NewReplacer(
+		mentionEveryone, "@"+zeroWidthSpace+strings.TrimPrefix(mentionEveryone, "@"),
+		mentionHere, "@"+zeroWidthSpace+strings.TrimPrefix(mentionHere, "@"),
+		mentionPrefix, mentionPrefix+zeroWidthSpace,
+	).Replace(text)
+}
+
+// sanitizeTemplate appends sanitizeFuncName to the pipeline of every action printing a value in
+// the templates associated with t, so that variables are sanitized while the text written by
+// translators is kept intact. Templates shared with partials are already sanitized.
+func sanitizeTemplate(t *template.Template, sanitizer sanitizer, partials *template.Template) {
+	for _, associated := range ownTemplates(t, partials) {
+		sanitizeNode(associated.Tree, associated.Root, sanitizer)
+	}
+}
+
+func sanitizeNode(tree *parse.Tree, node parse.Node, sanitizer sanitizer) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			sanitizeNode(tree, child, sanitizer)
+		}
+	case *parse.ActionNode:
+		// Declarations and assignments do not print anything
+		if len(n.Pipe.Decl) > 0 {
+			return
+		}
+		n.Pipe.Cmds = append(n.Pipe.Cmds, &parse.CommandNode{
+			NodeType: parse.NodeCommand,
+			Pos:      n.Pos,
+			Args: []parse.Node{
+				parse.NewIdentifier(sanitizeFuncName).SetTree(tree).SetPos(n.Pos),
+				&parse.BoolNode{NodeType: parse.NodeBool, Pos: n.Pos, True: sanitizer.escapeMarkdown},
+				&parse.BoolNode{NodeType: parse.NodeBool, Pos: n.Pos, True: sanitizer.neutralizeMentions},
+			},
+		})
+	case *parse.IfNode:
+		sanitizeNode(tree, n.List, sanitizer)
+		sanitizeNode(tree, n.ElseList, sanitizer)
+	case *parse.RangeNode:
+		sanitizeNode(tree, n.List, sanitizer)
+		sanitizeNode(tree, n.ElseList, sanitizer)
+	case *parse.WithNode:
+		sanitizeNode(tree, n.List, sanitizer)
+		sanitizeNode(tree, n.ElseList, sanitizer)
+	}
+}
+
+// AllowedMentions returns the mentions a rendered message contains, so that Discord only
+// notifies them: @everyone and @here, users and roles. Mentions neutralized in variables
+// are ignored, which makes it suitable for messages rendered with mentions neutralization.
+func AllowedMentions(content string) *discordgo.MessageAllowedMentions {
+	allowed := &discordgo.MessageAllowedMentions{Parse: []discordgo.AllowedMentionType{}}
+	if strings.Contains(content, mentionEveryone) || strings.Contains(content, mentionHere) {
+		allowed.Parse = append(allowed.Parse, discordgo.AllowedMentionTypeEveryone)
+	}
+
+	for rest := content; ; {
+		start := strings.Index(rest, mentionPrefix)
+		if start < 0 {
+			break
+		}
+		rest = rest[start+len(mentionPrefix):]
+
+		isRole := strings.HasPrefix(rest, rolePrefix)
+		id := strings.TrimPrefix(strings.TrimPrefix(rest, rolePrefix), nicknamePrefix)
+		end := strings.IndexRune(id, mentionSuffix)
+		if end <= 0 {
+			continue
+		}
+
+		if _, err := snowflake(id[:end]); err != nil {
+			continue
+		}
+
+		if isRole {
+			allowed.Roles = appendUnique(allowed.Roles, id[:end])
+		} else {
+			allowed.Users = appendUnique(allowed.Users, id[:end])
+		}
+	}
+
+	return allowed
+}
+
+func appendUnique(values []string, value string) []string {
+	for _, existing := range values {
+		if existing == value {
+			return values
+		}
+	}
+
+	return append(values, value)
+}
@@ -0,0 +1,108 @@
+package discordgoi18n
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test sanitizing values
+func TestSanitize(t *testing.T) {
+	both := sanitizer{escapeMarkdown: true, neutralizeMentions: true}
+	for value, expected := range map[any]Markdown{
+		"Nick":                      "Nick",
+		"@everyone":                 "@\u200beveryone",
+		"**@here**":                 "\\*\\*@\u200bhere\\*\\*",
+		"<@80351110224678912>":      "<@\u200b80351110224678912>",
+		"<@!80351110224678912>":     "<@\u200b!80351110224678912>",
+		"<@&41771983423143936>":     "<@\u200b&41771983423143936>",
+		"<#41771983423143937>":      "<#41771983423143937>",
+		"nick@example.com":          "nick@example.com",
+		Markdown("@everyone **x**"): "@everyone **x**",
+		42:                          "42",
+	} {
+		assert.Equal(t, expected, both.sanitize(value), value)
+	}
+
+	assert.Equal(t, Markdown("**@\u200beveryone**"), sanitizer{neutralizeMentions: true}.sanitize("**@everyone**"))
+	assert.Equal(t, Markdown("\\*\\*@everyone\\*\\*"), sanitizer{escapeMarkdown: true}.sanitize("**@everyone**"))
+	assert.False(t, sanitizer{}.enabled())
+	assert.True(t, sanitizer{neutralizeMentions: true}.enabled())
+}
+
+// Test neutralizing mentions injected in templates
+func TestSanitizeTemplateMentions(t *testing.T) {
+	variables := Vars{"nick": "@everyone", "user": &discordgo.User{ID: "80351110224678912"}}
+	for raw, expected := range map[string]string{
+		"Hello {{ .nick }}":                  "Hello @\u200beveryone",
+		"@here, welcome {{ mention .user }}": "@here, welcome <@80351110224678912>",
+		"{{ raw .nick }}":                    "@everyone",
+		"**{{ .nick }}**":                    "**@\u200beveryone**",
+	} {
+		msg, err := newMessage("mentions", raw, compileOptions{syntax: SyntaxTemplate, sanitizer: sanitizer{neutralizeMentions: true}})
+		assert.NoError(t, err, raw)
+
+		var buf strings.Builder
+		assert.NoError(t, msg.template.Funcs(templateFuncs(defaultLocale)).Execute(&buf, variables), raw)
+		assert.Equal(t, expected, buf.String(), raw)
+	}
+}
+
+// Test listing allowed mentions of rendered messages
+func TestAllowedMentions(t *testing.T) {
+	allowed := AllowedMentions("Hello <@80351110224678912>, <@!80351110224678913> and <@&41771983423143936>! " +
+		"<@80351110224678912> <#41771983423143937> <@\u200b80351110224678914> <@abc> <@> <@80351110224678915")
+	assert.Equal(t, []string{"80351110224678912", "80351110224678913"}, allowed.Users)
+	assert.Equal(t, []string{"41771983423143936"}, allowed.Roles)
+	assert.Empty(t, allowed.Parse)
+
+	allowed = AllowedMentions("@here, @\u200beveryone")
+	assert.Equal(t, []discordgo.AllowedMentionType{discordgo.AllowedMentionTypeEveryone}, allowed.Parse)
+	assert.Empty(t, allowed.Users)
+	assert.Empty(t, allowed.Roles)
+
+	allowed = AllowedMentions("@\u200beveryone @\u200bhere")
+	assert.Equal(t, []discordgo.AllowedMentionType{}, allowed.Parse)
+}
+
+// Test escaping variables injected in templates
+func TestSanitizeTemplateEscaping(t *testing.T) {
+	variables := Vars{"nick": "**admin**", "nicks": []string{"_a_", "b"}, "bold": Markdown("**b**")}
+	for raw, expected := range map[string]string{
+		"**{{ .nick }}**":                                    "**\\*\\*admin\\*\\***",
+		"{{ .nick | printf \"%s!\" }}":                       "\\*\\*admin\\*\\*!",
+		"{{ raw .nick }} {{ .nick | safe }}":                 "**admin** **admin**",
+		"{{ .bold }}":                                        "**b**",
+		"{{ escapeMarkdown .nick }}":                         "\\*\\*admin\\*\\*",
+		"{{ if .nick }}_{{ .nick }}_{{ else }}none{{ end }}": "_\\*\\*admin\\*\\*_",
+		"{{ range .nicks }}{{ . }} {{ end }}":                "\\_a\\_ b ",
+		"{{ with .nick }}{{ . }}{{ end }}":                   "\\*\\*admin\\*\\*",
+		"{{ $n := .nick }}{{ $n }}":                          "\\*\\*admin\\*\\*",
+		"{{ mention \"80351110224678912\" }}":                "<@80351110224678912>",
+		"{{ emoji \"mm_lol:216154654256398347\" }}":          "<:mm_lol:216154654256398347>",
+	} {
+		msg, err := newMessage("escape", raw, compileOptions{syntax: SyntaxTemplate, sanitizer: sanitizer{escapeMarkdown: true}})
+		assert.NoError(t, err, raw)
+
+		var buf strings.Builder
+		assert.NoError(t, msg.template.Funcs(templateFuncs(defaultLocale)).Execute(&buf, variables), raw)
+		assert.Equal(t, expected, buf.String(), raw)
+	}
+}
+
+// Test escaping variables injected in ICU messages
+func TestSanitizeICUEscaping(t *testing.T) {
+	msg, err := newMessage("escape", "*{nick}* has {n, number} {n, plural, one {coin} other {coins}}",
+		compileOptions{syntax: SyntaxICU, sanitizer: sanitizer{escapeMarkdown: true}})
+	assert.NoError(t, err)
+
+	translation, err := msg.render(defaultLocale, defaultLocale, Vars{"nick": "__Nick__", "n": -1234}, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "*\\_\\_Nick\\_\\_* has -1,234 coins", translation)
+
+	translation, err = msg.render(defaultLocale, defaultLocale, Vars{"nick": Markdown("__Nick__"), "n": 1}, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "*__Nick__* has 1 coin", translation)
+}
@@ -0,0 +1,79 @@
+package discordgoi18n
+
+import (
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	// selectKey declares the variable selecting the case of a select object.
+	selectKey   = "$select"
+	selectOther = "other"
+)
+
+// isSelectContent returns true when content declares the variable selecting its cases.
+func isSelectContent(content map[string]any) bool {
+	_, found := content[selectKey]
+	return found
+}
+
+// newSelectEntry compiles every case of content, each case being a raw, an array of raws,
+// a plural object or another select object. The other case is mandatory since it is used
+// for any value without a dedicated case.
+func newSelectEntry(key string, content map[string]any, options compileOptions) (*entry, error) {
+	selector, isString := content[selectKey].(string)
+	if !isString || selector == "" {
+		return nil, fmt.Errorf("select variable '%v' of key '%s' is not a variable name", content[selectKey], key)
+	}
+
+	if _, found := content[selectOther]; !found {
+		return nil, fmt.Errorf("select object of key '%s' does not declare the '%s' case", key, selectOther)
+	}
+
+	selectEntry := &entry{selector: selector, cases: make(map[string]*entry, len(content)-1)}
+	for selectCase, value := range content {
+		if selectCase == selectKey {
+			continue
+		}
+
+		caseEntry, err := newCaseEntry(key, value, options)
+		if err != nil {
+			return nil, err
+		}
+		selectEntry.cases[selectCase] = caseEntry
+	}
+
+	return selectEntry, nil
+}
+
+func newCaseEntry(key string, value any, options compileOptions) (*entry, error) {
+	content, isMap := value.(map[string]any)
+	switch {
+	case isMap && isSelectContent(content):
+		return newSelectEntry(key, content, options)
+	case isMap && isPluralContent(content):
+		return newPluralEntry(key, content, options)
+	case isMap:
+		return nil, fmt.Errorf("select case of key '%s' is neither a raw, a plural nor a select object", key)
+	}
+
+	messages, err := newMessages(key, value, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entry{messages: messages}, nil
+}
+
+// resolveCase resolves the case of the entry matching the value of its selector variable,
+// the other case being used when the variable is missing or its case is not provided.
+func (entry *entry) resolveCase(locale discordgo.Locale, count any, variables Vars) ([]*message, error) {
+	if value, found := variables[entry.selector]; found && value != nil {
+		if selected, found := entry.cases[fmt.Sprint(value)]; found {
+			return selected.resolve(locale, count, variables)
+		}
+	}
+
+	return entry.cases[selectOther].resolve(locale, count, variables)
+}
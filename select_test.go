@@ -0,0 +1,71 @@
+package discordgoi18n
+
+import (
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test compiling select objects
+func TestNewSelectEntry(t *testing.T) {
+	options := compileOptions{syntax: SyntaxTemplate}
+	selectEntry, err := newSelectEntry("joined", map[string]any{
+		selectKey: "gender",
+		"female":  "Elle a rejoint",
+		"male":    []any{"Il a rejoint", "Il est arrivé"},
+		"other":   map[string]any{"$plural": "cardinal", "one": "Un membre a rejoint", "other": "{{ .count }} membres ont rejoint"},
+	}, options)
+	assert.NoError(t, err)
+	assert.Equal(t, "gender", selectEntry.selector)
+	assert.Len(t, selectEntry.cases, 3)
+	assert.Len(t, selectEntry.allMessages(), 5)
+	assert.False(t, selectEntry.isEmpty())
+
+	for _, content := range []map[string]any{
+		{selectKey: "gender", "female": "Elle"},
+		{selectKey: "", "other": "Iel"},
+		{selectKey: 1, "other": "Iel"},
+		{selectKey: "gender", "other": map[string]any{"nested": "Iel"}},
+		{selectKey: "gender", "other": "{{ .name"},
+		{selectKey: "gender", "other": map[string]any{selectKey: "count"}},
+	} {
+		_, err = newSelectEntry("joined", content, options)
+		assert.Error(t, err, content)
+	}
+}
+
+// Test resolving select cases
+func TestResolveCase(t *testing.T) {
+	selectEntry, err := newSelectEntry("joined", map[string]any{
+		selectKey: "gender",
+		"female":  "Elle a rejoint",
+		"true":    "Vrai",
+		"other": map[string]any{
+			selectKey: "bot",
+			"true":    "Un bot a rejoint",
+			"other":   map[string]any{"$plural": "cardinal", "one": "Un membre a rejoint", "other": "Des membres ont rejoint"},
+		},
+	}, compileOptions{syntax: SyntaxTemplate})
+	assert.NoError(t, err)
+
+	for _, test := range []struct {
+		variables Vars
+		count     any
+		expected  string
+	}{
+		{Vars{"gender": "female"}, nil, "Elle a rejoint"},
+		{Vars{"gender": true}, nil, "Vrai"},
+		{Vars{"gender": "male", "bot": true}, nil, "Un bot a rejoint"},
+		{Vars{"gender": "male"}, 1, "Un membre a rejoint"},
+		{Vars{"gender": nil}, 2, "Des membres ont rejoint"},
+		{nil, 1, "Un membre a rejoint"},
+	} {
+		messages, err := selectEntry.resolve(discordgo.French, test.count, test.variables)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, messages[0].raw, test)
+	}
+
+	_, err = selectEntry.resolve(discordgo.French, "many", Vars{"gender": "male"})
+	assert.Error(t, err)
+}
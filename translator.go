@@ -1,13 +1,13 @@
 package discordgoi18n
 
 import (
-	"bytes"
-	"encoding/json"
 	"fmt"
 	"io/fs"
+	"maps"
 	"math/rand"
 	"os"
-	"strings"
+	"reflect"
+	"slices"
 	"text/template"
 
 	"github.com/bwmarrin/discordgo"
@@ -15,224 +15,690 @@ import (
 )
 
 const (
-	defaultLocale   = discordgo.EnglishUS
-	leftDelim       = "{{"
-	rightDelim      = "}}"
-	keyDelim        = "."
-	executionPolicy = "missingkey=error"
+	defaultLocale = discordgo.EnglishUS
+	keyDelim      = "."
+	syntaxKey     = "$syntax"
 )
 
 func NewTranslator(logger logger.Logger) Translator {
-	return &translatorImpl{
-		defaultLocale: defaultLocale,
-		translations:  make(map[discordgo.Locale]bundle),
-		loadedBundles: make(map[string]bundle),
-		logger:        logger,
+	translator := &translatorImpl{
+		logger: logger,
 	}
+
+	translator.state.Store(&translatorState{
+		defaultLocale:    defaultLocale,
+		fallbacks:        defaultFallbacks(),
+		syntax:           SyntaxTemplate,
+		loadMode:         LoadModeReplace,
+		conflictPolicy:   ConflictError,
+		localeValidation: LocaleValidationLenient,
+		translations:     make(map[discordgo.Locale]bundle),
+		layers:           make(map[discordgo.Locale][]bundleLayer),
+		overwrittenKeys:  make(map[discordgo.Locale][]string),
+		loadedBundles:    make(map[string]bundleLayer),
+	})
+
+	return translator
 }
 
 func (translator *translatorImpl) SetDefault(locale discordgo.Locale) {
-	translator.defaultLocale = locale
+	translator.update(func(state *translatorState) {
+		state.defaultLocale = locale
+	})
+}
+
+func (translator *translatorImpl) SetFallbacks(locale discordgo.Locale, fallbacks ...discordgo.Locale) {
+	translator.update(func(state *translatorState) {
+		state.fallbacks[locale] = slices.Clone(fallbacks)
+	})
+}
+
+func (translator *translatorImpl) SetSyntax(syntax Syntax) {
+	translator.update(func(state *translatorState) {
+		state.syntax = syntax
+	})
+}
+
+func (translator *translatorImpl) SetMarkdownEscaping(enabled bool) {
+	translator.updateOptions(func(state *translatorState) {
+		state.escapeMarkdown = enabled
+	})
+}
+
+func (translator *translatorImpl) SetMentionNeutralization(enabled bool) {
+	translator.updateOptions(func(state *translatorState) {
+		state.neutralizeMentions = enabled
+	})
+}
+
+func (translator *translatorImpl) SetLoadMode(mode LoadMode) {
+	translator.update(func(state *translatorState) {
+		state.loadMode = mode
+	})
+}
+
+func (translator *translatorImpl) SetConflictPolicy(policy ConflictPolicy) {
+	translator.update(func(state *translatorState) {
+		state.conflictPolicy = policy
+	})
+}
+
+func (translator *translatorImpl) SetLocaleValidation(validation LocaleValidation) {
+	translator.update(func(state *translatorState) {
+		state.localeValidation = validation
+	})
+}
+
+func (translator *translatorImpl) AddFuncs(funcs template.FuncMap) error {
+	if err := validateFuncs(funcs); err != nil {
+		return err
+	}
+
+	translator.updateOptions(func(state *translatorState) {
+		state.funcs = state.funcs.withGlobal(funcs)
+	})
+	return nil
+}
+
+func (translator *translatorImpl) AddLocaleFuncs(locale discordgo.Locale, funcs template.FuncMap) error {
+	if err := validateFuncs(funcs); err != nil {
+		return err
+	}
+
+	translator.updateOptions(func(state *translatorState) {
+		state.funcs = state.funcs.withLocale(locale, funcs)
+	})
+	return nil
 }
 
 func (translator *translatorImpl) LoadBundle(locale discordgo.Locale, path string) error {
+	return translator.LoadBundleFormat(locale, path, formatOf(path))
+}
+
+func (translator *translatorImpl) LoadBundleFormat(locale discordgo.Locale, path string, format Format) error {
 	cachePath := translator.buildCachePath(path, osSource)
-	loadedBundle, found := translator.loadedBundles[cachePath]
+	loadedLayer, found := translator.state.Load().loadedBundles[cachePath]
 	if !found {
 		buf, err := os.ReadFile(path)
 		if err != nil {
 			return err
 		}
 
-		return translator.loadBundleBuf(locale, buf, cachePath)
+		return translator.loadBundleBuf(locale, path, buf, cachePath, format)
 	}
 
-	translator.translations[locale] = loadedBundle
-	return nil
+	return translator.storeBundle(locale, loadedLayer)
 }
 
 func (translator *translatorImpl) LoadBundleFS(locale discordgo.Locale, fsys fs.FS, path string) error {
+	return translator.LoadBundleFSFormat(locale, fsys, path, formatOf(path))
+}
+
+func (translator *translatorImpl) LoadBundleFSFormat(locale discordgo.Locale, fsys fs.FS, path string,
+	format Format) error {
 	cachePath := translator.buildCachePath(path, fsSource)
-	loadedBundle, found := translator.loadedBundles[cachePath]
+	loadedLayer, found := translator.state.Load().loadedBundles[cachePath]
 	if !found {
 		buf, err := fs.ReadFile(fsys, path)
 		if err != nil {
 			return err
 		}
 
-		return translator.loadBundleBuf(locale, buf, cachePath)
+		return translator.loadBundleBuf(locale, path, buf, cachePath, format)
 	}
 
-	translator.translations[locale] = loadedBundle
-	return nil
+	return translator.storeBundle(locale, loadedLayer)
 }
 
 func (translator *translatorImpl) LoadBundleContent(locale discordgo.Locale, content map[string]any) error {
 	cachePath := translator.buildCachePath(fmt.Sprintf("%p", content), contentSource)
-	loadedBundle, found := translator.loadedBundles[cachePath]
+	state := translator.state.Load()
+	loadedLayer, found := state.loadedBundles[cachePath]
 	if !found {
-		newBundle := translator.mapBundleStructure(content)
-		translator.loadedBundles[cachePath] = newBundle
-		translator.translations[locale] = newBundle
-		return nil
+		var err error
+		loadedLayer, err = translator.newLayer(state, locale, cachePath, content)
+		if err != nil {
+			return err
+		}
 	}
 
-	translator.translations[locale] = loadedBundle
-	return nil
+	return translator.storeBundle(locale, loadedLayer)
+}
+
+// GetOverwrittenKeys returns the sorted keys of locale declared by several merged bundles,
+// whose translations have been overwritten or ignored according to the conflict policy.
+func (translator *translatorImpl) GetOverwrittenKeys(locale discordgo.Locale) []string {
+	return slices.Clone(translator.state.Load().overwrittenKeys[locale])
 }
 
 func (translator *translatorImpl) Get(locale discordgo.Locale, key string, variables Vars) string {
-	bundles, found := translator.translations[locale]
+	return translator.translate(locale, key, variables[countVariable], variables)
+}
+
+func (translator *translatorImpl) GetArray(locale discordgo.Locale, key string, variables Vars) []string {
+	messages, entryLocale, found := translator.resolve(translator.state.Load(), locale, key,
+		variables[countVariable], variables)
 	if !found {
-		if locale != translator.defaultLocale {
-			translator.logger.Error().Err(fmt.Errorf("bundle '%s' is not loaded, trying to translate key '%s' in '%s'",
-				locale, key, translator.defaultLocale))
-			return key
+		return []string{key}
+	}
+
+	// Always work on a fresh slice: messages belong to the bundle shared by every caller.
+	translations := make([]string, len(messages))
+	for i, message := range messages {
+		translation, err := message.render(locale, entryLocale, variables, 0)
+		if err != nil {
+			translator.logger.Error().Err(err).Msgf("Cannot translate raw corresponding to key '%s' in '%s', key returned", key, locale)
+			return []string{key}
 		}
 
-		translator.logger.Error().Err(fmt.Errorf("bundle '%s' is not loaded, cannot translate '%s', key returned", locale, key))
-		return key
+		translations[i] = translation
 	}
 
-	raws, found := bundles[key]
-	if !found || len(raws) == 0 {
-		if locale != translator.defaultLocale {
-			translator.logger.Error().Err(fmt.Errorf("no label found for key '%s' in '%s', trying to translate it in %s",
-				key, locale, translator.defaultLocale))
-			return key
-		}
+	return translations
+}
+
+func (translator *translatorImpl) GetPlural(locale discordgo.Locale, key string, count any, variables Vars) string {
+	return translator.translate(locale, key, count, withVariable(variables, countVariable, count))
+}
+
+func (translator *translatorImpl) GetDefault(key string, variables Vars) string {
+	return translator.Get(translator.state.Load().defaultLocale, key, variables)
+}
+
+func (translator *translatorImpl) GetDefaultArray(key string, variables Vars) []string {
+	return translator.GetArray(translator.state.Load().defaultLocale, key, variables)
+}
+
+func (translator *translatorImpl) GetLocalizations(key string, variables Vars) *map[discordgo.Locale]string {
+	localizations := make(map[discordgo.Locale]string)
+
+	for locale := range translator.state.Load().localizableLocales() {
+		localizations[locale] = translator.Get(locale, key, variables)
+	}
+
+	return &localizations
+}
 
-		translator.logger.Error().Err(fmt.Errorf("no label found for key '%s' in '%s'", key, locale))
+// translate renders one of the messages bound to key in locale, key being returned if
+// any translation cannot be found or an error occurred.
+func (translator *translatorImpl) translate(locale discordgo.Locale, key string, count any, variables Vars) string {
+	messages, entryLocale, found := translator.resolve(translator.state.Load(), locale, key, count, variables)
+	if !found {
 		return key
 	}
 
 	//nolint:gosec // No need to have a strong random number generator here.
-	raw := raws[rand.Intn(len(raws))]
+	message := messages[rand.Intn(len(messages))]
 
-	if variables != nil && strings.Contains(raw, leftDelim) {
-		t, err := template.New("").Delims(leftDelim, rightDelim).Option(executionPolicy).Parse(raw)
-		if err != nil {
-			translator.logger.Error().Err(err).Msgf("Cannot parse raw corresponding to key '%s' in '%s'", locale, key)
-			return key
-		}
+	translation, err := message.render(locale, entryLocale, variables, 0)
+	if err != nil {
+		translator.logger.Error().Err(err).Msgf("Cannot translate raw corresponding to key '%s' in '%s', key returned", key, locale)
+		return key
+	}
 
-		var buf bytes.Buffer
-		err = t.Execute(&buf, variables)
-		if err != nil {
-			translator.logger.Error().Err(err).Msgf("Cannot inject variables in raw corresponding to key '%s' in '%s', raw returned", locale, key)
-			return key
-		}
-		return buf.String()
+	return translation
+}
+
+// renderReference renders key in locale as a translation nested at depth in another one,
+// failing when the key cannot be found.
+func (translator *translatorImpl) renderReference(locale discordgo.Locale, key string, variables Vars,
+	depth int) (string, error) {
+	messages, entryLocale, found := translator.resolve(translator.state.Load(), locale, key,
+		variables[countVariable], variables)
+	if !found {
+		return "", fmt.Errorf("referenced key '%s' cannot be found in '%s'", key, locale)
 	}
 
-	return raw
+	//nolint:gosec // No need to have a strong random number generator here.
+	message := messages[rand.Intn(len(messages))]
+	return message.render(locale, entryLocale, variables, depth)
 }
 
-func (translator *translatorImpl) GetArray(locale discordgo.Locale, key string, variables Vars) []string {
-	bundles, found := translator.translations[locale]
+// resolve retrieves the messages bound to key in locale; cases are selected with variables and
+// plural forms with count according to the rules of the locale the key has been found in, which
+// is returned as well.
+func (translator *translatorImpl) resolve(state *translatorState, locale discordgo.Locale, key string,
+	count any, variables Vars) ([]*message, discordgo.Locale, bool) {
+	entry, entryLocale, found := translator.lookup(state, locale, key)
 	if !found {
-		if locale != translator.defaultLocale {
-			translator.logger.Error().Err(fmt.Errorf("bundle '%s' is not loaded, trying to translate key '%s' in '%s'",
-				locale, key, translator.defaultLocale))
-			return []string{key}
+		return nil, "", false
+	}
+
+	messages, err := entry.resolve(entryLocale, count, variables)
+	if err != nil {
+		translator.logger.Error().Err(err).Msgf("Cannot select form of key '%s' in '%s', key returned", key, entryLocale)
+		return nil, "", false
+	}
+
+	return messages, entryLocale, true
+}
+
+// lookup retrieves the entry bound to key in locale, walking through its fallback chain and
+// finally the default locale when the bundle is not loaded or does not contain the key.
+// The locale where the entry has been found is returned along with it. Fallback hops are
+// expected for regional variants and only logged at debug level, unlike missing keys.
+func (translator *translatorImpl) lookup(state *translatorState, locale discordgo.Locale,
+	key string) (*entry, discordgo.Locale, bool) {
+	entry, err := state.lookupBundle(locale, key)
+	if err == nil {
+		return entry, locale, true
+	}
+
+	fallbacks := state.fallbacks[locale]
+	for _, fallback := range fallbacks {
+		if fallback == locale {
+			continue
 		}
 
-		translator.logger.Error().Err(fmt.Errorf("bundle '%s' is not loaded, cannot translate '%s', key returned", locale, key))
-		return []string{key}
+		translator.logger.Debug().Err(err).Msgf("Trying to translate key '%s' in '%s'", key, fallback)
+		entry, err = state.lookupBundle(fallback, key)
+		if err == nil {
+			return entry, fallback, true
+		}
 	}
 
-	raws, found := bundles[key]
-	if !found || len(raws) == 0 {
-		if locale != translator.defaultLocale {
-			translator.logger.Error().Err(fmt.Errorf("no label found for key '%s' in '%s'", key, locale))
-			return []string{key}
+	if locale != state.defaultLocale && !slices.Contains(fallbacks, state.defaultLocale) {
+		translator.logger.Debug().Err(err).Msgf("Trying to translate key '%s' in '%s'", key, state.defaultLocale)
+		entry, err = state.lookupBundle(state.defaultLocale, key)
+		if err == nil {
+			return entry, state.defaultLocale, true
 		}
+	}
 
-		translator.logger.Error().Err(fmt.Errorf("no label found for key '%s' in '%s'", key, locale))
-		return []string{key}
+	translator.logger.Error().Err(err).Msgf("Cannot translate key '%s', key returned", key)
+	return nil, "", false
+}
+
+// update applies mutate on a copy of the current state then publishes it; writers are serialized
+// while readers keep working on the snapshot they loaded.
+func (translator *translatorImpl) update(mutate func(state *translatorState)) {
+	translator.mutex.Lock()
+	defer translator.mutex.Unlock()
+
+	state := translator.state.Load().clone()
+	mutate(state)
+	translator.state.Store(state)
+}
+
+// updateOptions applies mutate on the compile options of a copy of the state, then compiles the
+// bundles of every locale again so that loaded bundles follow the new options too. Bundles keep
+// the syntax they have been loaded with; the ones which cannot be compiled anymore are kept as is.
+func (translator *translatorImpl) updateOptions(mutate func(state *translatorState)) {
+	var errs []error
+	translator.update(func(state *translatorState) {
+		mutate(state)
+		state.optionsVersion++
+		errs = translator.recompileLayers(state)
+	})
+
+	for _, err := range errs {
+		translator.logger.Error().Err(err).Msg("Bundle kept with the previous options")
 	}
+}
 
-	//nolint:gosec // No need to have a strong random number generator here.
-	for i, raw := range raws {
-		if variables != nil && strings.Contains(raw, leftDelim) {
-			t, err := template.New("").Delims(leftDelim, rightDelim).Option(executionPolicy).Parse(raw)
+// recompileLayers compiles the layers of every locale of state again with its options. Merged
+// bundles keep the entries the conflict policy selected when they were stored, and only the
+// recompiled layers remain cached.
+func (translator *translatorImpl) recompileLayers(state *translatorState) []error {
+	var errs []error
+	state.loadedBundles = make(map[string]bundleLayer)
+	for locale, layers := range state.layers {
+		recompiled := make([]bundleLayer, 0, len(layers))
+		for _, layer := range layers {
+			current, err := translator.compileLayer(state, layer.syntax, layer.cachePath, layer.content, layer.shared)
 			if err != nil {
-				translator.logger.Error().Err(err).Msgf("Cannot parse raw corresponding to key '%s' in '%s'", key, locale)
-				return []string{key}
+				errs = append(errs, fmt.Errorf("cannot compile bundle '%s' of '%s': %w", layer.cachePath, locale, err))
+				recompiled = append(recompiled, layer)
+				continue
 			}
 
-			var buf bytes.Buffer
-			err = t.Execute(&buf, variables)
-			if err != nil {
-				translator.logger.Error().Err(err).Msgf("Cannot inject variables in raw corresponding to key '%s' in '%s'", key, locale)
-				return []string{key}
-			}
+			recompiled = append(recompiled, current)
+			state.loadedBundles[current.cachePath] = current
+		}
 
-			raws[i] = buf.String()
+		merged := make(bundle, len(state.translations[locale]))
+		for key, entry := range state.translations[locale] {
+			merged[key] = entry
+			for i, layer := range layers {
+				if layer.bundle[key] == entry {
+					merged[key] = recompiled[i].bundle[key]
+					break
+				}
+			}
 		}
+
+		state.layers[locale] = recompiled
+		state.translations[locale] = merged
 	}
 
-	return raws
+	return errs
 }
 
-func (translator *translatorImpl) GetDefault(key string, variables Vars) string {
-	return translator.Get(translator.defaultLocale, key, variables)
+func (translator *translatorImpl) storeBundle(locale discordgo.Locale, layer bundleLayer) error {
+	return translator.storeBundles(locale, []bundleLayer{layer})
 }
 
-func (translator *translatorImpl) GetDefaultArray(key string, variables Vars) []string {
-	return translator.GetArray(translator.defaultLocale, key, variables)
+// storeBundles caches the bundles of newLayers and stores them for the validated locale according
+// to the load mode: either as the only bundles of locale or merged with the ones already loaded,
+// a bundle loaded again from the same cachePath replacing its previous version. Bundles compiled
+// before options or the partials and constants of the locale changed are compiled again with the
+// current ones. The state is left untouched when a bundle cannot be compiled or merged bundles
+// conflict with ConflictError policy.
+func (translator *translatorImpl) storeBundles(locale discordgo.Locale, newLayers []bundleLayer) error {
+	locale, err := translator.validateLocale(locale)
+	if err != nil {
+		return err
+	}
+
+	var (
+		overwritten []string
+		policy      ConflictPolicy
+	)
+
+	translator.update(func(state *translatorState) {
+		layers := newLayers
+		switch state.loadMode {
+		case LoadModeReplace:
+		case LoadModeMerge:
+			layers = state.layers[locale]
+			for _, layer := range newLayers {
+				layers = withLayer(layers, layer)
+			}
+		default:
+			err = fmt.Errorf("unknown load mode '%s'", state.loadMode)
+			return
+		}
+
+		policy = state.conflictPolicy
+		layers, err = translator.currentLayers(state, layers, newLayers)
+		if err != nil {
+			return
+		}
+
+		var merged bundle
+		merged, overwritten, err = mergeLayers(layers, policy)
+		if err != nil {
+			return
+		}
+
+		for _, layer := range layers {
+			state.loadedBundles[layer.cachePath] = layer
+		}
+		state.translations[locale] = merged
+		state.layers[locale] = layers
+		state.overwrittenKeys[locale] = overwritten
+	})
+
+	for _, key := range overwritten {
+		translator.logger.Warn().Msgf("Key '%s' is declared by several bundles of '%s', %s one kept",
+			key, locale, policy)
+	}
+
+	return err
 }
 
-func (translator *translatorImpl) GetLocalizations(key string, variables Vars) *map[discordgo.Locale]string {
-	localizations := make(map[discordgo.Locale]string)
+// currentLayers returns the layers of a locale compiled with the options of state and the partials
+// and constants they share, the outdated ones being compiled again. newLayers are compiled with the
+// syntax of state while the layers already stored keep their own.
+func (translator *translatorImpl) currentLayers(state *translatorState, layers,
+	newLayers []bundleLayer) ([]bundleLayer, error) {
+	shared, err := newSharedTemplates(layers, state.conflictPolicy)
+	if err != nil {
+		return nil, err
+	}
 
-	for locale := range translator.translations {
-		localizations[locale] = translator.Get(locale, key, variables)
+	layers = slices.Clone(layers)
+	for i, layer := range layers {
+		syntax := layer.syntax
+		if slices.ContainsFunc(newLayers, func(newLayer bundleLayer) bool {
+			return newLayer.cachePath == layer.cachePath
+		}) {
+			syntax = state.syntax
+		}
+
+		if layer.bundle != nil && layer.version == state.optionsVersion && layer.syntax == syntax &&
+			reflect.DeepEqual(layer.shared, shared) {
+			continue
+		}
+
+		current, err := translator.compileLayer(state, syntax, layer.cachePath, layer.content, shared)
+		if err != nil {
+			return nil, fmt.Errorf("cannot compile bundle '%s': %w", layer.cachePath, err)
+		}
+		layers[i] = current
 	}
 
-	return &localizations
+	return layers, nil
 }
 
-func (translator *translatorImpl) loadBundleBuf(locale discordgo.Locale, buf []byte, cachePath string) error {
-	var jsonContent map[string]any
-	err := json.Unmarshal(buf, &jsonContent)
+func (translator *translatorImpl) loadBundleBuf(locale discordgo.Locale, file string, buf []byte, cachePath string,
+	format Format) error {
+	layer, err := translator.parseBundleBuf(locale, file, buf, cachePath, format)
 	if err != nil {
 		return err
 	}
 
-	newBundle := translator.mapBundleStructure(jsonContent)
-
 	translator.logger.Debug().Msgf("Bundle '%s' loaded with '%s' content", locale, cachePath)
-	translator.loadedBundles[cachePath] = newBundle
-	translator.translations[locale] = newBundle
-	return nil
+	return translator.storeBundle(locale, layer)
+}
+
+// parseBundleBuf decodes buf, the content of file written in format, and compiles it as the
+// layer of cachePath for locale; errors report file.
+func (translator *translatorImpl) parseBundleBuf(locale discordgo.Locale, file string, buf []byte, cachePath string,
+	format Format) (bundleLayer, error) {
+	content, err := decodeBundle(buf, format)
+	if err != nil {
+		return bundleLayer{}, fmt.Errorf("cannot decode bundle '%s': %w", file, err)
+	}
+
+	layer, err := translator.newLayer(translator.state.Load(), locale, cachePath, content)
+	if err != nil {
+		return bundleLayer{}, fmt.Errorf("cannot compile bundle '%s': %w", file, err)
+	}
+
+	return layer, nil
 }
 
-func (translator *translatorImpl) mapBundleStructure(jsonContent map[string]any) bundle {
-	bundle := make(map[string][]string)
-	for key, content := range jsonContent {
-		switch v := content.(type) {
-		case string:
-			bundle[key] = []string{v}
-		case []any:
-			values := make([]string, 0)
-			for _, value := range v {
-				values = append(values, fmt.Sprintf("%v", value))
+// newLayer compiles content loaded from cachePath with the options of state, along with the
+// partials and constants of the bundles of locale it is merged with.
+func (translator *translatorImpl) newLayer(state *translatorState, locale discordgo.Locale, cachePath string,
+	content map[string]any) (bundleLayer, error) {
+	layers := []bundleLayer{{cachePath: cachePath, content: content}}
+	if state.loadMode == LoadModeMerge {
+		if normalized, found := parseLocale(string(locale)); found {
+			locale = normalized
+		}
+		layers = withLayer(state.layers[locale], layers[0])
+	}
+
+	shared, err := newSharedTemplates(layers, state.conflictPolicy)
+	if err != nil {
+		return bundleLayer{}, err
+	}
+
+	return translator.compileLayer(state, state.syntax, cachePath, content, shared)
+}
+
+// compileLayer compiles content loaded from cachePath with syntax, the options of state and the
+// partials and constants shared by its locale.
+func (translator *translatorImpl) compileLayer(state *translatorState, syntax Syntax, cachePath string,
+	content map[string]any, shared sharedTemplates) (bundleLayer, error) {
+	compiled, err := translator.compileBundle(state, syntax, content, shared)
+	if err != nil {
+		return bundleLayer{}, err
+	}
+
+	return bundleLayer{cachePath: cachePath, content: content, bundle: compiled, syntax: syntax,
+		version: state.optionsVersion, shared: shared}, nil
+}
+
+// compileBundle compiles the bundle content with syntax and the options of state, using the
+// syntax the bundle declares through syntaxKey instead if any, the shared partials and constants
+// and its notes.
+func (translator *translatorImpl) compileBundle(state *translatorState, syntax Syntax,
+	content map[string]any, shared sharedTemplates) (bundle, error) {
+	options := compileOptions{
+		syntax:     syntax,
+		sanitizer:  sanitizer{escapeMarkdown: state.escapeMarkdown, neutralizeMentions: state.neutralizeMentions},
+		funcs:      state.funcs,
+		references: translator.renderReference,
+	}
+	if declared, found := content[syntaxKey]; found {
+		declaredSyntax, isString := declared.(string)
+		if !isString {
+			return nil, fmt.Errorf("bundle syntax '%v' is not a string", declared)
+		}
+
+		options.syntax = Syntax(declaredSyntax)
+	}
+
+	// Bundles written with another syntax only reject the partials and constants they declare
+	if options.syntax == SyntaxTemplate || content[partialsKey] != nil || content[constantsKey] != nil {
+		partials, err := newPartials(shared.partials, shared.constants, options)
+		if err != nil {
+			return nil, err
+		}
+		options.partials = partials
+	}
+
+	content = maps.Clone(content)
+	notes := content[notesKey]
+	delete(content, syntaxKey)
+	delete(content, partialsKey)
+	delete(content, constantsKey)
+	delete(content, notesKey)
+
+	compiledBundle, err := translator.mapBundleStructure("", content, options)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = compiledBundle.setNotes(notes); err != nil {
+		return nil, err
+	}
+
+	if err = compiledBundle.checkReferences(); err != nil {
+		return nil, err
+	}
+
+	return compiledBundle, nil
+}
+
+// mapBundleStructure flattens the bundle content found under prefix into keys joined by keyDelim
+// and compiles every value, so that invalid templates are rejected at load time. Values are
+// compiled with their full key, naming templates and errors. Objects declaring pluralKey are kept
+// as plural forms and objects declaring selectKey as cases instead of being flattened.
+func (translator *translatorImpl) mapBundleStructure(prefix string, jsonContent map[string]any,
+	options compileOptions) (bundle, error) {
+	bundle := make(map[string]*entry)
+	for name, content := range jsonContent {
+		key := name
+		if prefix != "" {
+			key = prefix + keyDelim + name
+		}
+
+		v, isMap := content.(map[string]any)
+		switch {
+		case isMap && isSelectContent(v):
+			selectEntry, err := newSelectEntry(key, v, options)
+			if err != nil {
+				return nil, err
+			}
+			bundle[key] = selectEntry
+		case isMap && isPluralContent(v):
+			pluralEntry, err := newPluralEntry(key, v, options)
+			if err != nil {
+				return nil, err
 			}
-			bundle[key] = values
-		case map[string]any:
-			subValues := translator.mapBundleStructure(v)
-			for subKey, subValue := range subValues {
-				bundle[fmt.Sprintf("%s%s%s", key, keyDelim, subKey)] = subValue
+			bundle[key] = pluralEntry
+		case isMap:
+			subValues, err := translator.mapBundleStructure(key, v, options)
+			if err != nil {
+				return nil, err
 			}
+			maps.Copy(bundle, subValues)
 		default:
-			bundle[key] = []string{fmt.Sprintf("%v", v)}
+			messages, err := newMessages(key, content, options)
+			if err != nil {
+				return nil, err
+			}
+			bundle[key] = &entry{messages: messages}
 		}
 	}
 
-	return bundle
+	return bundle, nil
 }
 
 func (translator *translatorImpl) buildCachePath(path string, source source) string {
 	return fmt.Sprintf("%v:%v", source, path)
 }
+
+func (state *translatorState) clone() *translatorState {
+	return &translatorState{
+		defaultLocale:      state.defaultLocale,
+		fallbacks:          maps.Clone(state.fallbacks),
+		syntax:             state.syntax,
+		escapeMarkdown:     state.escapeMarkdown,
+		neutralizeMentions: state.neutralizeMentions,
+		funcs:              state.funcs,
+		loadMode:           state.loadMode,
+		conflictPolicy:     state.conflictPolicy,
+		localeValidation:   state.localeValidation,
+		optionsVersion:     state.optionsVersion,
+		translations:       maps.Clone(state.translations),
+		layers:             maps.Clone(state.layers),
+		overwrittenKeys:    maps.Clone(state.overwrittenKeys),
+		loadedBundles:      maps.Clone(state.loadedBundles),
+	}
+}
+
+func (state *translatorState) lookupBundle(locale discordgo.Locale, key string) (*entry, error) {
+	bundles, found := state.translations[locale]
+	if !found {
+		return nil, fmt.Errorf("bundle '%s' is not loaded", locale)
+	}
+
+	entry, found := bundles[key]
+	if !found || entry.isEmpty() {
+		return nil, fmt.Errorf("no label found for key '%s' in '%s'", key, locale)
+	}
+
+	return entry, nil
+}
+
+// localizableLocales returns the locales which have a loaded bundle, either directly or through
+// their fallback chain; the default locale is not considered to avoid localizing every locale.
+func (state *translatorState) localizableLocales() map[discordgo.Locale]struct{} {
+	locales := make(map[discordgo.Locale]struct{})
+	for locale := range state.translations {
+		locales[locale] = struct{}{}
+	}
+
+	for locale := range discordgo.Locales {
+		if locale == discordgo.Unknown {
+			continue
+		}
+
+		for _, fallback := range state.fallbacks[locale] {
+			if _, found := state.translations[fallback]; found {
+				locales[locale] = struct{}{}
+				break
+			}
+		}
+	}
+
+	return locales
+}
+
+// defaultFallbacks returns the built-in fallback chains between Discord regional variants,
+// so a single bundle can serve every variant of a language.
+func defaultFallbacks() map[discordgo.Locale][]discordgo.Locale {
+	return map[discordgo.Locale][]discordgo.Locale{
+		discordgo.EnglishUS:    {discordgo.EnglishGB},
+		discordgo.EnglishGB:    {discordgo.EnglishUS},
+		discordgo.SpanishES:    {discordgo.SpanishLATAM},
+		discordgo.SpanishLATAM: {discordgo.SpanishES},
+		discordgo.ChineseCN:    {discordgo.ChineseTW},
+		discordgo.ChineseTW:    {discordgo.ChineseCN},
+	}
+}
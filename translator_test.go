@@ -1,8 +1,16 @@
 package discordgoi18n
 
 import (
+	"bytes"
+	"encoding/binary"
 	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
+	"testing/fstest"
+	"text/template"
+	"time"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/kstoums/discordgo-i18n/logger"
@@ -14,6 +22,7 @@ const (
 	translatorNominalCase1         = "translatorNominalCase1.json"
 	translatorNominalCase2         = "translatorNominalCase2.json"
 	translatorFailedUnmarshallCase = "translatorFailedUnmarshallCase.json"
+	translatorFailedTemplateCase   = "translatorFailedTemplateCase.json"
 	translatorFileDoesNotExistCase = "translatorFileDoesNotExistCase.json"
 
 	content1 = `
@@ -23,8 +32,7 @@ const (
        "the": ["elements", "we"],
        "can": ["find"],
        "in": ["a","json"],
-       "config": ["file", "! {{ .Author }}"],
-       "parse": ["{{if $foo}}{{end}}"]
+       "config": ["file", "! {{ .Author }}"]
     }
     `
 
@@ -32,8 +40,14 @@ const (
     {
        "this": ["is a {{ .Test }}"],
        "with.a.file": ["containing", "less", "variables"],
-       "bye": ["see you"],
-       "parse2": ["{{if $foo}}{{end}}"]
+       "bye": ["see you"]
+    }
+    `
+
+	badTemplateContent = `
+    {
+       "hi": "all good",
+       "parse": ["{{if $foo}}{{end}}"]
     }
     `
 
@@ -56,13 +70,15 @@ var (
 		"can":    []string{"find"},
 		"in":     []string{"a", "json"},
 		"config": []string{"file", "! {{ .Author }}"},
-		"parse":  []string{"{{if $foo}}{{end}}"},
 	}
 	content2Map = map[string]any{
 		"this":        []string{"is a {{ .Test }}"},
 		"with.a.file": []string{"containing", "less", "variables"},
 		"bye":         []string{"see you"},
-		"parse2":      []string{"{{if $foo}}{{end}}"},
+	}
+	badTemplateContentMap = map[string]any{
+		"hi":    "all good",
+		"parse": "{{if $foo}}{{end}}",
 	}
 )
 
@@ -75,6 +91,7 @@ func setUp() {
 		translatorNominalCase1:         content1,
 		translatorNominalCase2:         content2,
 		translatorFailedUnmarshallCase: badContent,
+		translatorFailedTemplateCase:   badTemplateContent,
 	} {
 		if err := os.WriteFile(name, []byte(content), os.ModePerm); err != nil {
 			log.Fatal().Err(err).Msgf("'%s' could not be created, test stopped", name)
@@ -88,6 +105,7 @@ func tearDown() {
 		translatorNominalCase1,
 		translatorNominalCase2,
 		translatorFailedUnmarshallCase,
+		translatorFailedTemplateCase,
 		translatorFileDoesNotExistCase,
 	} {
 		if err := os.Remove(f); err != nil {
@@ -100,17 +118,164 @@ func tearDown() {
 func TestNewTranslator(t *testing.T) {
 	setUp()
 	defer tearDown()
-	assert.Empty(t, translatorTest.translations)  // No translations loaded initially
-	assert.Empty(t, translatorTest.loadedBundles) // No bundles loaded initially
+	assert.Empty(t, translatorTest.state.Load().translations)  // No translations loaded initially
+	assert.Empty(t, translatorTest.state.Load().loadedBundles) // No bundles loaded initially
 }
 
 // Test setting default locale
 func TestSetDefault(t *testing.T) {
 	setUp()
 	defer tearDown()
-	assert.Equal(t, defaultLocale, translatorTest.defaultLocale)
+	assert.Equal(t, defaultLocale, translatorTest.state.Load().defaultLocale)
 	translatorTest.SetDefault(discordgo.Italian)
-	assert.Equal(t, discordgo.Italian, translatorTest.defaultLocale)
+	assert.Equal(t, discordgo.Italian, translatorTest.state.Load().defaultLocale)
+}
+
+// Test setting fallback chains
+func TestSetFallbacks(t *testing.T) {
+	setUp()
+	defer tearDown()
+
+	// Built-in chains between regional variants
+	assert.Equal(t, []discordgo.Locale{discordgo.SpanishES}, translatorTest.state.Load().fallbacks[discordgo.SpanishLATAM])
+	assert.Equal(t, []discordgo.Locale{discordgo.EnglishUS}, translatorTest.state.Load().fallbacks[discordgo.EnglishGB])
+	assert.Empty(t, translatorTest.state.Load().fallbacks[discordgo.PortugueseBR])
+
+	translatorTest.SetFallbacks(discordgo.PortugueseBR, discordgo.SpanishES, discordgo.SpanishLATAM)
+	assert.Equal(t, []discordgo.Locale{discordgo.SpanishES, discordgo.SpanishLATAM}, translatorTest.state.Load().fallbacks[discordgo.PortugueseBR])
+
+	translatorTest.SetFallbacks(discordgo.SpanishLATAM)
+	assert.Empty(t, translatorTest.state.Load().fallbacks[discordgo.SpanishLATAM])
+}
+
+// Test setting the bundle syntax
+func TestSetSyntax(t *testing.T) {
+	setUp()
+	defer tearDown()
+
+	assert.Equal(t, SyntaxTemplate, translatorTest.state.Load().syntax)
+	assert.NoError(t, translatorTest.LoadBundle(discordgo.French, translatorNominalCase1))
+	assert.Equal(t, 1, len(translatorTest.state.Load().loadedBundles))
+
+	// Loaded bundles keep their syntax while bundles loaded afterwards use the new one
+	translatorTest.SetSyntax(SyntaxICU)
+	assert.Equal(t, SyntaxICU, translatorTest.state.Load().syntax)
+	assert.Equal(t, "this is a **test**", translatorTest.Get(discordgo.French, "hi", Vars{"Test": "**test**"}))
+	assert.NoError(t, translatorTest.LoadBundleContent(discordgo.German, map[string]any{"hi": "Hallo {name}"}))
+	assert.Equal(t, "Hallo Nick", translatorTest.Get(discordgo.German, "hi", Vars{"name": "Nick"}))
+
+	// Cached bundles are compiled again when loaded with another syntax
+	assert.Error(t, translatorTest.LoadBundle(discordgo.French, translatorNominalCase1))
+	assert.Equal(t, "this is a **test**", translatorTest.Get(discordgo.French, "hi", Vars{"Test": "**test**"}))
+}
+
+// Test escaping Markdown of injected variables
+func TestSetMarkdownEscaping(t *testing.T) {
+	setUp()
+	defer tearDown()
+
+	assert.False(t, translatorTest.state.Load().escapeMarkdown)
+	assert.NoError(t, translatorTest.LoadBundle(discordgo.French, translatorNominalCase1))
+	assert.Equal(t, "this is a **test**", translatorTest.Get(discordgo.French, "hi", Vars{"Test": "**test**"}))
+
+	// Bundles already loaded are escaped too
+	translatorTest.SetMarkdownEscaping(true)
+	assert.True(t, translatorTest.state.Load().escapeMarkdown)
+	assert.Equal(t, "this is a \\*\\*test\\*\\*", translatorTest.Get(discordgo.French, "hi", Vars{"Test": "**test**"}))
+	assert.Equal(t, "this is a **test**", translatorTest.Get(discordgo.French, "hi", Vars{"Test": Markdown("**test**")}))
+	assert.Equal(t, []string{"this is a {{ .Test }}"}, translatorTest.GetArray(discordgo.French, "hi", nil))
+
+	assert.NoError(t, translatorTest.LoadBundleContent(discordgo.German, map[string]any{
+		"bold":  "**{{ .anyone }}** {{ raw .anyone }}",
+		"coins": map[string]any{"$plural": "cardinal", "one": "{{ .count }} _coin_", "other": "{{ number .count }} _coins_"},
+	}))
+	assert.Equal(t, "**\\_Nick\\_** _Nick_", translatorTest.Get(discordgo.German, "bold", Vars{"anyone": "_Nick_"}))
+	assert.Equal(t, "1.234 _coins_", translatorTest.GetPlural(discordgo.German, "coins", 1234, nil))
+	assert.Equal(t, "**\\|\\|x\\|\\|** ||x||", (*translatorTest.GetLocalizations("bold", Vars{"anyone": "||x||"}))[discordgo.German])
+
+	// Formatted dates and numbers are never escaped
+	assert.NoError(t, translatorTest.LoadBundleContent(discordgo.Italian, map[string]any{
+		"when": "{{ date .when \"long\" }}, {{ .name }}, {{ number .count }} {{ currency \"EUR\" .count }}",
+	}))
+	translatorTest.SetFallbacks(discordgo.German, discordgo.Italian)
+	assert.Equal(t, "5. März 2024, 1\\. Nick, 1.234,5 1.234,50\u00a0€", translatorTest.Get(discordgo.German, "when",
+		Vars{"name": "1. Nick", "when": time.Date(2024, time.March, 5, 0, 0, 0, 0, time.UTC), "count": 1234.5}))
+
+	translatorTest.SetMarkdownEscaping(false)
+	assert.Equal(t, "**_Nick_** _Nick_", translatorTest.Get(discordgo.German, "bold", Vars{"anyone": "_Nick_"}))
+	assert.Equal(t, "this is a **test**", translatorTest.Get(discordgo.French, "hi", Vars{"Test": "**test**"}))
+}
+
+// Test neutralizing mentions of injected variables
+func TestSetMentionNeutralization(t *testing.T) {
+	setUp()
+	defer tearDown()
+
+	content := map[string]any{"hello": "@here, welcome {{ .anyone }} ({{ mention .User }})"}
+	variables := Vars{"anyone": "@everyone", "User": "80351110224678912"}
+	assert.NoError(t, translatorTest.LoadBundleContent(discordgo.French, content))
+	assert.Equal(t, "@here, welcome @everyone (<@80351110224678912>)", translatorTest.Get(discordgo.French, "hello", variables))
+
+	// Bundles already loaded are neutralized too
+	translatorTest.SetMentionNeutralization(true)
+	assert.True(t, translatorTest.state.Load().neutralizeMentions)
+	translation := translatorTest.Get(discordgo.French, "hello", variables)
+	assert.Equal(t, "@here, welcome @\u200beveryone (<@80351110224678912>)", translation)
+	assert.Equal(t, &discordgo.MessageAllowedMentions{
+		Parse: []discordgo.AllowedMentionType{discordgo.AllowedMentionTypeEveryone},
+		Users: []string{"80351110224678912"},
+	}, AllowedMentions(translation))
+
+	// Both modes at once, bundles being compiled again with the syntax they have been loaded with
+	translatorTest.SetSyntax(SyntaxICU)
+	assert.NoError(t, translatorTest.LoadBundleContent(discordgo.German, map[string]any{"hello": "Hallo {anyone}!"}))
+	translatorTest.SetSyntax(SyntaxTemplate)
+	translatorTest.SetMarkdownEscaping(true)
+	assert.Equal(t, "Hallo \\*\\*@\u200bhere\\*\\*!", translatorTest.Get(discordgo.German, "hello", Vars{"anyone": "**@here**"}))
+}
+
+// Test registering custom template functions
+func TestAddFuncs(t *testing.T) {
+	setUp()
+	defer tearDown()
+
+	content := map[string]any{"hello": "{{ greet (upper .anyone) }}!"}
+	assert.NoError(t, translatorTest.LoadBundle(discordgo.French, translatorNominalCase1))
+	assert.NoError(t, translatorTest.AddFuncs(template.FuncMap{"upper": strings.ToUpper}))
+	assert.NoError(t, translatorTest.AddLocaleFuncs(discordgo.French, template.FuncMap{
+		"greet": func(name string) string { return "Salut " + name },
+	}))
+	assert.NoError(t, translatorTest.LoadBundleContent(discordgo.German, content))
+	assert.Equal(t, "hello", translatorTest.Get(discordgo.German, "hello", Vars{"anyone": "Nick"}))
+	assert.NoError(t, translatorTest.AddLocaleFuncs(discordgo.German, template.FuncMap{
+		"greet": func(name string) string { return "Hallo " + name },
+	}))
+
+	// Loaded bundles are compiled again with the new functions
+	assert.Equal(t, "Hallo NICK!", translatorTest.Get(discordgo.German, "hello", Vars{"anyone": "Nick"}))
+	assert.Len(t, translatorTest.state.Load().loadedBundles, 2)
+	for _, layer := range translatorTest.state.Load().loadedBundles {
+		assert.Equal(t, translatorTest.state.Load().optionsVersion, layer.version)
+	}
+	assert.Len(t, translatorTest.state.Load().funcs.global, 1)
+	assert.Len(t, translatorTest.state.Load().funcs.locales, 2)
+
+	// Collisions and invalid functions are rejected without modifying the state
+	assert.Error(t, translatorTest.AddFuncs(template.FuncMap{"mention": strings.ToUpper}))
+	assert.Error(t, translatorTest.AddLocaleFuncs(discordgo.French, template.FuncMap{"len": strings.ToUpper}))
+	assert.Error(t, translatorTest.AddFuncs(template.FuncMap{"lower": "lower"}))
+	assert.Len(t, translatorTest.state.Load().funcs.global, 1)
+	assert.Len(t, translatorTest.state.Load().funcs.locales[discordgo.French], 1)
+
+	assert.NoError(t, translatorTest.LoadBundleContent(discordgo.French, content))
+	assert.NoError(t, translatorTest.LoadBundleContent(discordgo.German, content))
+	assert.NoError(t, translatorTest.LoadBundleContent(discordgo.Italian, content))
+	assert.Equal(t, "Salut NICK!", translatorTest.Get(discordgo.French, "hello", Vars{"anyone": "Nick"}))
+	assert.Equal(t, "Hallo NICK!", translatorTest.Get(discordgo.German, "hello", Vars{"anyone": "Nick"}))
+	assert.Equal(t, "hello", translatorTest.Get(discordgo.Italian, "hello", Vars{"anyone": "Nick"}))
+
+	// Unknown functions are still rejected at load time
+	assert.Error(t, translatorTest.LoadBundleContent(discordgo.Italian, map[string]any{"hello": "{{ lower .anyone }}"}))
 }
 
 // Test loading JSON bundles from files
@@ -120,35 +285,466 @@ func TestLoadBundle(t *testing.T) {
 
 	// Nonexistent file returns an error and does not modify state
 	assert.Error(t, translatorTest.LoadBundle(discordgo.French, translatorFileDoesNotExistCase))
-	assert.Empty(t, translatorTest.translations)
-	assert.Empty(t, translatorTest.loadedBundles)
+	assert.Empty(t, translatorTest.state.Load().translations)
+	assert.Empty(t, translatorTest.state.Load().loadedBundles)
 
 	// Malformed JSON returns an error
 	assert.Error(t, translatorTest.LoadBundle(discordgo.French, translatorFailedUnmarshallCase))
-	assert.Empty(t, translatorTest.translations)
-	assert.Empty(t, translatorTest.loadedBundles)
+	assert.Empty(t, translatorTest.state.Load().translations)
+	assert.Empty(t, translatorTest.state.Load().loadedBundles)
+
+	// Invalid template returns an error
+	assert.Error(t, translatorTest.LoadBundle(discordgo.French, translatorFailedTemplateCase))
+	assert.Empty(t, translatorTest.state.Load().translations)
+	assert.Empty(t, translatorTest.state.Load().loadedBundles)
 
 	// Load valid bundles
 	assert.NoError(t, translatorTest.LoadBundle(discordgo.French, translatorNominalCase1))
-	assert.Equal(t, 1, len(translatorTest.loadedBundles))
-	assert.Equal(t, 1, len(translatorTest.translations))
-	assert.Equal(t, 7, len(translatorTest.translations[discordgo.French]))
+	assert.Equal(t, 1, len(translatorTest.state.Load().loadedBundles))
+	assert.Equal(t, 1, len(translatorTest.state.Load().translations))
+	assert.Equal(t, 6, len(translatorTest.state.Load().translations[discordgo.French]))
 
 	assert.NoError(t, translatorTest.LoadBundle(discordgo.French, translatorNominalCase2))
-	assert.Equal(t, 2, len(translatorTest.loadedBundles))
-	assert.Equal(t, 1, len(translatorTest.translations))
-	assert.Equal(t, 4, len(translatorTest.translations[discordgo.French]))
+	assert.Equal(t, 2, len(translatorTest.state.Load().loadedBundles))
+	assert.Equal(t, 1, len(translatorTest.state.Load().translations))
+	assert.Equal(t, 3, len(translatorTest.state.Load().translations[discordgo.French]))
 
 	// Load bundles for different locale
 	assert.NoError(t, translatorTest.LoadBundle(discordgo.EnglishGB, translatorNominalCase2))
-	assert.Equal(t, 2, len(translatorTest.loadedBundles))
-	assert.Equal(t, 2, len(translatorTest.translations))
-	assert.Equal(t, 4, len(translatorTest.translations[discordgo.EnglishGB]))
+	assert.Equal(t, 2, len(translatorTest.state.Load().loadedBundles))
+	assert.Equal(t, 2, len(translatorTest.state.Load().translations))
+	assert.Equal(t, 3, len(translatorTest.state.Load().translations[discordgo.EnglishGB]))
 
 	assert.NoError(t, translatorTest.LoadBundle(discordgo.EnglishGB, translatorNominalCase1))
-	assert.Equal(t, 2, len(translatorTest.loadedBundles))
-	assert.Equal(t, 2, len(translatorTest.translations))
-	assert.Equal(t, 7, len(translatorTest.translations[discordgo.EnglishGB]))
+	assert.Equal(t, 2, len(translatorTest.state.Load().loadedBundles))
+	assert.Equal(t, 2, len(translatorTest.state.Load().translations))
+	assert.Equal(t, 6, len(translatorTest.state.Load().translations[discordgo.EnglishGB]))
+}
+
+// Test bundles compiled before options changed are compiled again once stored
+func TestStoreBundleOutdated(t *testing.T) {
+	setUp()
+	defer tearDown()
+
+	layer, err := translatorTest.compileLayer(translatorTest.state.Load(), SyntaxTemplate, "content:hello",
+		map[string]any{"hello": "Hello {{ .anyone }}"}, sharedTemplates{})
+	assert.NoError(t, err)
+
+	// Escaping enabled while the bundle was being compiled
+	translatorTest.SetMarkdownEscaping(true)
+	assert.NoError(t, translatorTest.storeBundle(discordgo.French, layer))
+	assert.Equal(t, "Hello \\*\\*Nick\\*\\*", translatorTest.Get(discordgo.French, "hello", Vars{"anyone": "**Nick**"}))
+	assert.Equal(t, translatorTest.state.Load().optionsVersion,
+		translatorTest.state.Load().loadedBundles["content:hello"].version)
+
+	// The state is left untouched when the bundle cannot be compiled with the current options
+	layer, err = translatorTest.compileLayer(translatorTest.state.Load(), SyntaxTemplate, "content:ping",
+		map[string]any{"ping": "{{ .x }}"}, sharedTemplates{})
+	assert.NoError(t, err)
+	translatorTest.SetSyntax(SyntaxICU)
+	assert.ErrorContains(t, translatorTest.storeBundle(discordgo.German, layer), "cannot compile bundle 'content:ping'")
+	assert.NotContains(t, translatorTest.state.Load().translations, discordgo.German)
+}
+
+// Test merging bundles loaded for the same locale
+func TestLoadBundleMerge(t *testing.T) {
+	setUp()
+	defer tearDown()
+
+	translatorTest.SetLoadMode(LoadModeMerge)
+	assert.NoError(t, translatorTest.LoadBundle(discordgo.French, translatorNominalCase1))
+	assert.NoError(t, translatorTest.LoadBundle(discordgo.French, translatorNominalCase2))
+	assert.Equal(t, 9, len(translatorTest.state.Load().translations[discordgo.French]))
+	assert.Equal(t, "find", translatorTest.Get(discordgo.French, "can", nil))
+	assert.Equal(t, "see you", translatorTest.Get(discordgo.French, "bye", nil))
+
+	// Loading a bundle again replaces its previous version instead of conflicting with it
+	assert.NoError(t, translatorTest.LoadBundle(discordgo.French, translatorNominalCase1))
+	assert.Equal(t, 9, len(translatorTest.state.Load().translations[discordgo.French]))
+	assert.Empty(t, translatorTest.GetOverwrittenKeys(discordgo.French))
+
+	// Conflicts are rejected by default without modifying the state
+	overrides := map[string]any{"can": "override", "bye": "override", "new": "key"}
+	assert.Error(t, translatorTest.LoadBundleContent(discordgo.French, overrides))
+	assert.Equal(t, 9, len(translatorTest.state.Load().translations[discordgo.French]))
+	assert.Equal(t, "find", translatorTest.Get(discordgo.French, "can", nil))
+
+	translatorTest.SetConflictPolicy(ConflictFirstWins)
+	assert.NoError(t, translatorTest.LoadBundleContent(discordgo.French, overrides))
+	assert.Equal(t, 10, len(translatorTest.state.Load().translations[discordgo.French]))
+	assert.Equal(t, "find", translatorTest.Get(discordgo.French, "can", nil))
+	assert.Equal(t, "key", translatorTest.Get(discordgo.French, "new", nil))
+	assert.Equal(t, []string{"bye", "can"}, translatorTest.GetOverwrittenKeys(discordgo.French))
+
+	translatorTest.SetConflictPolicy(ConflictLastWins)
+	assert.NoError(t, translatorTest.LoadBundleContent(discordgo.French, overrides))
+	assert.Equal(t, "override", translatorTest.Get(discordgo.French, "can", nil))
+	assert.Equal(t, "override", translatorTest.Get(discordgo.French, "bye", nil))
+	assert.Equal(t, []string{"bye", "can"}, translatorTest.GetOverwrittenKeys(discordgo.French))
+
+	// Merged bundles compiled again with new options keep the translations selected when loaded
+	translatorTest.SetConflictPolicy(ConflictFirstWins)
+	assert.NoError(t, translatorTest.AddFuncs(template.FuncMap{"upper": strings.ToUpper}))
+	assert.Equal(t, 10, len(translatorTest.state.Load().translations[discordgo.French]))
+	assert.Equal(t, "override", translatorTest.Get(discordgo.French, "can", nil))
+	assert.Equal(t, "override", translatorTest.Get(discordgo.French, "bye", nil))
+	assert.Equal(t, []string{"bye", "can"}, translatorTest.GetOverwrittenKeys(discordgo.French))
+	translatorTest.SetConflictPolicy(ConflictLastWins)
+
+	// Cycles between merged bundles are rejected
+	assert.NoError(t, translatorTest.LoadBundleContent(discordgo.French, map[string]any{"loop": `{{ t "can" }}`}))
+	assert.Error(t, translatorTest.LoadBundleContent(discordgo.French, map[string]any{"can": `{{ t "loop" }}`}))
+
+	// Replacing bundles resets the merged ones and their report
+	translatorTest.SetLoadMode(LoadModeReplace)
+	assert.NoError(t, translatorTest.LoadBundle(discordgo.French, translatorNominalCase2))
+	assert.Equal(t, 3, len(translatorTest.state.Load().translations[discordgo.French]))
+	assert.Empty(t, translatorTest.GetOverwrittenKeys(discordgo.French))
+
+	translatorTest.SetLoadMode("unknown")
+	assert.Error(t, translatorTest.LoadBundle(discordgo.French, translatorNominalCase1))
+}
+
+// Test validating locales bundles are loaded for
+func TestSetLocaleValidation(t *testing.T) {
+	setUp()
+	defer tearDown()
+
+	// Common mistakes are normalized
+	assert.NoError(t, translatorTest.LoadBundleContent("fr-FR", map[string]any{"hello": "Bonjour"}))
+	assert.NoError(t, translatorTest.LoadBundleContent("pt", map[string]any{"hello": "Olá"}))
+	assert.NoError(t, translatorTest.LoadBundle("en", translatorNominalCase2))
+	assert.Equal(t, "Bonjour", translatorTest.Get(discordgo.French, "hello", nil))
+	assert.Equal(t, "Olá", translatorTest.Get(discordgo.PortugueseBR, "hello", nil))
+	assert.Equal(t, "see you", translatorTest.Get(discordgo.EnglishUS, "bye", nil))
+	assert.NotContains(t, translatorTest.state.Load().translations, discordgo.Locale("fr-FR"))
+
+	// Unsupported locales are kept in lenient mode
+	assert.NoError(t, translatorTest.LoadBundleContent("tlh", map[string]any{"hello": "nuqneH"}))
+	assert.Contains(t, translatorTest.state.Load().translations, discordgo.Locale("tlh"))
+
+	translatorTest.SetLocaleValidation(LocaleValidationStrict)
+	assert.Error(t, translatorTest.LoadBundleContent("xx", map[string]any{"hello": "?"}))
+	assert.Error(t, translatorTest.LoadBundle(discordgo.Unknown, translatorNominalCase1))
+	assert.NotContains(t, translatorTest.state.Load().translations, discordgo.Locale("xx"))
+	assert.NoError(t, translatorTest.LoadBundleContent("de-DE", map[string]any{"hello": "Hallo"}))
+	assert.Equal(t, "Hallo", translatorTest.Get(discordgo.German, "hello", nil))
+
+	translatorTest.SetLocaleValidation("unknown")
+	assert.Error(t, translatorTest.LoadBundleContent("xx", map[string]any{"hello": "?"}))
+}
+
+// Test loading YAML bundles
+func TestLoadBundleYAML(t *testing.T) {
+	setUp()
+	defer tearDown()
+
+	dir := t.TempDir()
+	yamlFile := filepath.Join(dir, "fr.yml")
+	assert.NoError(t, os.WriteFile(yamlFile, []byte(`
+hello: Bonjour {{ .name }}
+command:
+  ping:
+    name: ping
+    description: |-
+      Répond pong,
+      sur deux lignes
+coins:
+  $plural: cardinal
+  one: "{{ .count }} pièce"
+  other: "{{ .count }} pièces"
+days: [lundi, mardi]
+`), os.ModePerm))
+	assert.NoError(t, translatorTest.LoadBundle(discordgo.French, yamlFile))
+	assert.Equal(t, "Bonjour Nick", translatorTest.Get(discordgo.French, "hello", Vars{"name": "Nick"}))
+	assert.Equal(t, "Répond pong,\nsur deux lignes", translatorTest.Get(discordgo.French, "command.ping.description", nil))
+	assert.Equal(t, "2 pièces", translatorTest.GetPlural(discordgo.French, "coins", 2, nil))
+	assert.Equal(t, []string{"lundi", "mardi"}, translatorTest.GetArray(discordgo.French, "days", nil))
+
+	// Files without extension need an explicit format
+	noExtension := filepath.Join(dir, "de")
+	assert.NoError(t, os.WriteFile(noExtension, []byte("hello: Hallo\n"), os.ModePerm))
+	assert.Error(t, translatorTest.LoadBundle(discordgo.German, noExtension))
+	assert.NoError(t, translatorTest.LoadBundleFormat(discordgo.German, noExtension, FormatYAML))
+	assert.Equal(t, "Hallo", translatorTest.Get(discordgo.German, "hello", nil))
+
+	fsys := fstest.MapFS{
+		"it.yaml":   {Data: []byte("hello: Ciao\n")},
+		"it":        {Data: []byte("hello: Ciao\n")},
+		"it.txt":    {Data: []byte("hello: Ciao\n")},
+		"bad.yaml":  {Data: []byte("hello: Ciao\nbye: Arrivederci\n  bad: indentation\n")},
+		"parse.yml": {Data: []byte("hello: \"{{ .name\"\n")},
+	}
+	assert.NoError(t, translatorTest.LoadBundleFS(discordgo.Italian, fsys, "it.yaml"))
+	assert.NoError(t, translatorTest.LoadBundleFSFormat(discordgo.Italian, fsys, "it", FormatYAML))
+	assert.Equal(t, "Ciao", translatorTest.Get(discordgo.Italian, "hello", nil))
+
+	// Errors report the file and the line
+	err := translatorTest.LoadBundleFS(discordgo.Italian, fsys, "bad.yaml")
+	assert.ErrorContains(t, err, "'bad.yaml'")
+	assert.ErrorContains(t, err, "line 3")
+	assert.ErrorContains(t, translatorTest.LoadBundleFS(discordgo.Italian, fsys, "parse.yml"), "'parse.yml'")
+	assert.Error(t, translatorTest.LoadBundleFSFormat(discordgo.Italian, fsys, "it.txt", "xml"))
+}
+
+// Test loading TOML bundles
+func TestLoadBundleTOML(t *testing.T) {
+	setUp()
+	defer tearDown()
+
+	fsys := fstest.MapFS{
+		"fr.toml": {Data: []byte(`
+hello = "Bonjour {{ .name }}"
+greetings = ["Salut", "Coucou"]
+
+[command.ping]
+name = "ping"
+description = "Répond pong"
+
+[coins]
+"$plural" = "cardinal"
+one = "{{ .count }} pièce"
+other = "{{ .count }} pièces"
+`)},
+		"bad.toml": {Data: []byte("hello = \"Bonjour\"\nhello = \"Salut\"\n")},
+	}
+	assert.NoError(t, translatorTest.LoadBundleFS(discordgo.French, fsys, "fr.toml"))
+	assert.Equal(t, "Bonjour Nick", translatorTest.Get(discordgo.French, "hello", Vars{"name": "Nick"}))
+	assert.Equal(t, "Répond pong", translatorTest.Get(discordgo.French, "command.ping.description", nil))
+	assert.Equal(t, "1 pièce", translatorTest.GetPlural(discordgo.French, "coins", 1, nil))
+	assert.Equal(t, []string{"Salut", "Coucou"}, translatorTest.GetArray(discordgo.French, "greetings", nil))
+
+	err := translatorTest.LoadBundleFS(discordgo.French, fsys, "bad.toml")
+	assert.ErrorContains(t, err, "'bad.toml'")
+	assert.ErrorContains(t, err, "line 2")
+}
+
+// Test loading gettext PO and MO files
+func TestLoadGettext(t *testing.T) {
+	setUp()
+	defer tearDown()
+
+	fsys := fstest.MapFS{
+		"ru.po": {Data: []byte(gettextRussianPO)},
+		"fr.mo": {Data: buildMO(binary.LittleEndian, map[string]string{
+			"":      "Plural-Forms: nplurals=2; plural=(n > 1);\n",
+			"hello": "Bonjour {{ .name }}",
+		})},
+		"bad.po":      {Data: []byte("msgid \"hello\"\nmsgstr \"Bonjour\n")},
+		"template.po": {Data: []byte("msgid \"hello\"\nmsgstr \"Bonjour {{ .name \"\n")},
+	}
+	assert.NoError(t, translatorTest.LoadGettextFS(discordgo.Russian, fsys, "ru.po", false))
+	assert.Equal(t, "Привет Nick", translatorTest.Get(discordgo.Russian, "hello", Vars{"name": "Nick"}))
+	assert.Equal(t, "Отвечает \"понг\"\n", translatorTest.Get(discordgo.Russian, "command.ping.description", nil))
+	assert.Equal(t, "22 монеты", translatorTest.GetPlural(discordgo.Russian, "coin", 22, nil))
+	assert.Equal(t, "draft", translatorTest.Get(discordgo.Russian, "draft", nil))
+
+	assert.NoError(t, translatorTest.LoadGettextFS(discordgo.Russian, fsys, "ru.po", true))
+	assert.Equal(t, "Черновик", translatorTest.Get(discordgo.Russian, "draft", nil))
+
+	assert.NoError(t, translatorTest.LoadGettextFS(discordgo.French, fsys, "fr.mo", false))
+	assert.Equal(t, "Bonjour Nick", translatorTest.Get(discordgo.French, "hello", Vars{"name": "Nick"}))
+
+	err := translatorTest.LoadGettextFS(discordgo.French, fsys, "bad.po", false)
+	assert.ErrorContains(t, err, "cannot decode gettext file 'bad.po'")
+	assert.ErrorContains(t, err, "line 2")
+	assert.ErrorContains(t, translatorTest.LoadGettextFS(discordgo.French, fsys, "template.po", false),
+		"cannot compile gettext file 'template.po'")
+	assert.Error(t, translatorTest.LoadGettextFS(discordgo.French, fsys, "missing.po", false))
+
+	path := filepath.Join(t.TempDir(), "fr.po")
+	assert.NoError(t, os.WriteFile(path, []byte("msgid \"hello\"\nmsgstr \"Salut\"\n"), 0o600))
+	assert.NoError(t, translatorTest.LoadGettext(discordgo.French, path, false))
+	assert.Equal(t, "Salut", translatorTest.Get(discordgo.French, "hello", nil))
+	assert.Error(t, translatorTest.LoadGettext(discordgo.French, path+".missing", false))
+}
+
+// Test exporting bundles to XLIFF with translator notes
+func TestExportXLIFF(t *testing.T) {
+	setUp()
+	defer tearDown()
+
+	assert.NoError(t, translatorTest.LoadBundleContent(discordgo.EnglishUS, map[string]any{
+		"hello": "Hello {{ .name }}",
+		"coins": map[string]any{"$plural": "cardinal", "one": "{{ .count }} coin", "other": "{{ .count }} coins"},
+		"$notes": map[string]any{
+			"hello": "Greets the user & their name",
+		},
+	}))
+	assert.NoError(t, translatorTest.LoadBundleContent(discordgo.Russian, map[string]any{
+		"hello": "Привет {{ .name }}",
+		"coins": map[string]any{"$plural": "cardinal", "one": "{{ .count }} монета", "other": "{{ .count }} монеты"},
+	}))
+
+	var buf bytes.Buffer
+	assert.NoError(t, translatorTest.ExportXLIFF(&buf, discordgo.EnglishUS, "ru-RU", XLIFFVersion12))
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<xliff xmlns="urn:oasis:names:tc:xliff:document:1.2" version="1.2">
+  <file original="en-US" source-language="en-US" target-language="ru" datatype="plaintext">
+    <body>
+      <trans-unit id="coins#$plural" translate="no">
+        <source>cardinal</source>
+      </trans-unit>
+      <trans-unit id="coins#one">
+        <source>{{ .count }} coin</source>
+        <target>{{ .count }} монета</target>
+      </trans-unit>
+      <trans-unit id="coins#few">
+        <source>{{ .count }} coins</source>
+      </trans-unit>
+      <trans-unit id="coins#many">
+        <source>{{ .count }} coins</source>
+      </trans-unit>
+      <trans-unit id="coins#other">
+        <source>{{ .count }} coins</source>
+        <target>{{ .count }} монеты</target>
+      </trans-unit>
+      <trans-unit id="hello">
+        <source>Hello {{ .name }}</source>
+        <target>Привет {{ .name }}</target>
+        <note>Greets the user &amp; their name</note>
+      </trans-unit>
+    </body>
+  </file>
+</xliff>
+`, buf.String())
+
+	buf.Reset()
+	assert.NoError(t, translatorTest.ExportXLIFF(&buf, discordgo.EnglishUS, "", XLIFFVersion20))
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<xliff xmlns="urn:oasis:names:tc:xliff:document:2.0" version="2.0" srcLang="en-US">
+  <file id="en-US">
+    <unit id="u1" name="coins#$plural" translate="no">
+      <segment>
+        <source>cardinal</source>
+      </segment>
+    </unit>
+    <unit id="u2" name="coins#one">
+      <segment>
+        <source>{{ .count }} coin</source>
+      </segment>
+    </unit>
+    <unit id="u3" name="coins#other">
+      <segment>
+        <source>{{ .count }} coins</source>
+      </segment>
+    </unit>
+    <unit id="u4" name="hello">
+      <notes>
+        <note>Greets the user &amp; their name</note>
+      </notes>
+      <segment>
+        <source>Hello {{ .name }}</source>
+      </segment>
+    </unit>
+  </file>
+</xliff>
+`, buf.String())
+
+	assert.ErrorContains(t, translatorTest.ExportXLIFF(&buf, discordgo.French, "", XLIFFVersion12),
+		"no bundle loaded for locale 'French'")
+	assert.ErrorContains(t, translatorTest.ExportXLIFF(&buf, discordgo.EnglishUS, "", "3.0"),
+		"unknown XLIFF version '3.0'")
+
+	assert.NoError(t, translatorTest.LoadBundleContent(discordgo.German, map[string]any{"issue#1": "Fehler"}))
+	assert.ErrorContains(t, translatorTest.ExportXLIFF(&buf, discordgo.German, "", XLIFFVersion12),
+		"key 'issue#1' cannot be exported to XLIFF")
+
+	for notes, expected := range map[string]any{
+		"note of key 'hello' is not a string":          map[string]any{"hello": 1},
+		"note of key 'missing' does not match any key": map[string]any{"missing": "Note"},
+		"are not an object":                            "Note",
+	} {
+		err := translatorTest.LoadBundleContent(discordgo.French, map[string]any{"hello": "Bonjour", "$notes": expected})
+		assert.ErrorContains(t, err, notes)
+	}
+}
+
+// Test importing XLIFF files exported then translated
+func TestLoadXLIFF(t *testing.T) {
+	setUp()
+	defer tearDown()
+
+	assert.NoError(t, translatorTest.LoadBundleContent(discordgo.EnglishUS, map[string]any{
+		"hello":     "Hello {{ .name }}",
+		"greetings": []any{"Hi", "Hey"},
+		"command":   map[string]any{"ping": map[string]any{"description": "Answers pong"}},
+		"coins":     map[string]any{"$plural": "cardinal", "one": "{{ .count }} coin", "other": "{{ .count }} coins"},
+		"invite": map[string]any{
+			"$select": "gender",
+			"female":  "She invites you",
+			"other":   "They invite you",
+		},
+		"$notes": map[string]any{"hello": "Greets the user"},
+	}))
+
+	for _, version := range []XLIFFVersion{XLIFFVersion12, XLIFFVersion20} {
+		var exported bytes.Buffer
+		assert.NoError(t, translatorTest.ExportXLIFF(&exported, discordgo.EnglishUS, "", version))
+
+		// Importing the sources as targets gives back the same export
+		fsys := fstest.MapFS{"en.xlf": {Data: []byte(targetsFromSources(exported.String()))}}
+		assert.NoError(t, translatorTest.LoadXLIFFFS(discordgo.EnglishGB, fsys, "en.xlf"))
+
+		var reexported bytes.Buffer
+		assert.NoError(t, translatorTest.ExportXLIFF(&reexported, discordgo.EnglishGB, "", version))
+		assert.Equal(t, strings.ReplaceAll(exported.String(), "en-US", "en-GB"), reexported.String(), version)
+	}
+
+	fsys := fstest.MapFS{
+		"fr.xlf": {Data: []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<xliff xmlns="urn:oasis:names:tc:xliff:document:1.2" version="1.2">
+  <file original="en-US" source-language="en-US" target-language="fr" datatype="plaintext">
+    <body>
+      <trans-unit id="coins#$plural" translate="no"><source>cardinal</source></trans-unit>
+      <trans-unit id="coins#one"><source>{{ .count }} coin</source><target>{{ .count }} pièce</target></trans-unit>
+      <trans-unit id="coins#many"><source>{{ .count }} coins</source></trans-unit>
+      <trans-unit id="coins#other"><source>{{ .count }} coins</source><target>{{ .count }} pièces</target></trans-unit>
+      <trans-unit id="command.ping.description"><source>Answers pong</source><target>Répond pong</target></trans-unit>
+      <trans-unit id="greetings[0]"><source>Hi</source><target>Salut</target></trans-unit>
+      <trans-unit id="greetings[1]"><source>Hey</source></trans-unit>
+      <trans-unit id="hello"><source>Hello {{ .name }}</source><target>Bonjour {{ .name }}</target><note>Greets the user</note></trans-unit>
+      <trans-unit id="invite#$select" translate="no"><source>gender</source></trans-unit>
+      <trans-unit id="invite#female"><source>She invites you</source><target>Elle t'invite</target></trans-unit>
+      <trans-unit id="invite#other"><source>They invite you</source><target>Iel t'invite</target></trans-unit>
+    </body>
+  </file>
+</xliff>`)},
+		"bad.xlf":      {Data: []byte(`<xliff version="1.0"></xliff>`)},
+		"template.xlf": {Data: []byte(`<xliff version="2.0"><file id="f"><unit id="hello"><segment><source>Hello</source><target>{{ .name</target></segment></unit></file></xliff>`)},
+	}
+	assert.NoError(t, translatorTest.LoadXLIFFFS(discordgo.French, fsys, "fr.xlf"))
+	assert.Equal(t, "Bonjour Nick", translatorTest.Get(discordgo.French, "hello", Vars{"name": "Nick"}))
+	assert.Equal(t, "Répond pong", translatorTest.Get(discordgo.French, "command.ping.description", nil))
+	assert.Equal(t, []string{"Salut"}, translatorTest.GetArray(discordgo.French, "greetings", nil))
+	assert.Equal(t, "2 pièces", translatorTest.GetPlural(discordgo.French, "coins", 2, nil))
+	assert.Equal(t, "Elle t'invite", translatorTest.Get(discordgo.French, "invite", Vars{"gender": "female"}))
+
+	assert.ErrorContains(t, translatorTest.LoadXLIFFFS(discordgo.French, fsys, "bad.xlf"),
+		"cannot decode XLIFF file 'bad.xlf'")
+	assert.ErrorContains(t, translatorTest.LoadXLIFFFS(discordgo.French, fsys, "template.xlf"),
+		"cannot compile XLIFF file 'template.xlf'")
+	assert.Error(t, translatorTest.LoadXLIFFFS(discordgo.French, fsys, "missing.xlf"))
+
+	path := filepath.Join(t.TempDir(), "fr.xlf")
+	assert.NoError(t, os.WriteFile(path, fsys["fr.xlf"].Data, 0o600))
+	assert.NoError(t, translatorTest.LoadXLIFF(discordgo.French, path))
+	assert.Equal(t, "Salut", translatorTest.Get(discordgo.French, "greetings", nil))
+	assert.Error(t, translatorTest.LoadXLIFF(discordgo.French, path+".missing"))
+}
+
+// targetsFromSources translates an exported XLIFF file by copying every source as its target.
+func targetsFromSources(exported string) string {
+	var buf strings.Builder
+	for _, line := range strings.SplitAfter(exported, "\n") {
+		buf.WriteString(line)
+		if source, found := strings.CutPrefix(strings.TrimSpace(line), "<source>"); found {
+			indent := line[:strings.Index(line, "<")]
+			buf.WriteString(indent + "<target>" + strings.TrimSuffix(source, "</source>") + "</target>\n")
+		}
+	}
+
+	return buf.String()
 }
 
 // Test loading bundles from an FS
@@ -159,6 +755,7 @@ func TestLoadBundleFS(t *testing.T) {
 
 	assert.Error(t, translatorTest.LoadBundleFS(discordgo.French, dirFS, translatorFileDoesNotExistCase))
 	assert.Error(t, translatorTest.LoadBundleFS(discordgo.French, dirFS, translatorFailedUnmarshallCase))
+	assert.Error(t, translatorTest.LoadBundleFS(discordgo.French, dirFS, translatorFailedTemplateCase))
 
 	// Load valid bundles
 	assert.NoError(t, translatorTest.LoadBundleFS(discordgo.French, dirFS, translatorNominalCase1))
@@ -175,6 +772,14 @@ func TestLoadBundleContent(t *testing.T) {
 	assert.NoError(t, translatorTest.LoadBundleContent(discordgo.French, content2Map))
 	assert.NoError(t, translatorTest.LoadBundleContent(discordgo.EnglishGB, content2Map))
 	assert.NoError(t, translatorTest.LoadBundleContent(discordgo.EnglishGB, content1Map))
+	assert.Error(t, translatorTest.LoadBundleContent(discordgo.Italian, badTemplateContentMap))
+	assert.NotContains(t, translatorTest.state.Load().translations, discordgo.Italian)
+
+	// String slices are arrays of raws like any slices
+	assert.Equal(t, []string{"containing", "less", "variables"},
+		translatorTest.GetArray(discordgo.French, "with.a.file", nil))
+	assert.Equal(t, []string{"file", "! Nick"}, translatorTest.GetArray(discordgo.EnglishGB, "config", Vars{"Author": "Nick"}))
+	assert.Equal(t, "see you", translatorTest.Get(discordgo.French, "bye", nil))
 }
 
 // Test getting single translations
@@ -199,15 +804,341 @@ func TestGet(t *testing.T) {
 	assert.Equal(t, "this is a test :)", translatorTest.Get(discordgo.Dutch, "hi", Vars{"Test": "test :)"}))
 
 	// Default locale fallback
-	assert.Equal(t, "bye", translatorTest.Get(discordgo.Dutch, "bye", nil))
+	assert.Equal(t, "see you", translatorTest.Get(discordgo.Dutch, "bye", nil))
+	assert.Equal(t, "is a test :)", translatorTest.Get(discordgo.Dutch, "this", Vars{"Test": "test :)"}))
 
-	// Invalid template returns key
-	assert.Equal(t, "parse", translatorTest.Get(discordgo.Dutch, "parse", Vars{}))
+	// Bundle not loaded: default locale fallback
+	assert.Equal(t, "see you", translatorTest.Get(discordgo.French, "bye", nil))
+
+	// Key absent from both requested and default locales returns the key itself
+	assert.Equal(t, "does_not_exist", translatorTest.Get(discordgo.French, "does_not_exist", nil))
 
 	// Missing variable: fallback to key
 	assert.Equal(t, "hi", translatorTest.Get(discordgo.Dutch, "hi", Vars{}))
 }
 
+// Test fallback chains resolution
+func TestGetFallbacks(t *testing.T) {
+	setUp()
+	defer tearDown()
+
+	assert.NoError(t, translatorTest.LoadBundle(discordgo.SpanishES, translatorNominalCase1))
+	assert.NoError(t, translatorTest.LoadBundle(defaultLocale, translatorNominalCase2))
+
+	// Built-in chain: es-419 -> es-ES -> en-US
+	assert.Equal(t, "find", translatorTest.Get(discordgo.SpanishLATAM, "can", nil))
+	assert.Equal(t, "see you", translatorTest.Get(discordgo.SpanishLATAM, "bye", nil))
+	assert.Equal(t, []string{"elements", "we"}, translatorTest.GetArray(discordgo.SpanishLATAM, "the", nil))
+
+	// Custom chain: pt-BR -> es-419 -> es-ES -> en-US
+	assert.Equal(t, "can", translatorTest.Get(discordgo.PortugueseBR, "can", nil))
+	translatorTest.SetFallbacks(discordgo.PortugueseBR, discordgo.SpanishLATAM, discordgo.SpanishES)
+	assert.Equal(t, "find", translatorTest.Get(discordgo.PortugueseBR, "can", nil))
+	assert.Equal(t, "see you", translatorTest.Get(discordgo.PortugueseBR, "bye", nil))
+
+	// Chain cleared: only the default locale remains
+	translatorTest.SetFallbacks(discordgo.SpanishLATAM)
+	assert.Equal(t, "can", translatorTest.Get(discordgo.SpanishLATAM, "can", nil))
+	assert.Equal(t, "see you", translatorTest.Get(discordgo.SpanishLATAM, "bye", nil))
+}
+
+// Test getting plural translations
+func TestGetPlural(t *testing.T) {
+	setUp()
+	defer tearDown()
+
+	assert.Equal(t, "coins", translatorTest.GetPlural(discordgo.Polish, "coins", 1, nil))
+
+	assert.NoError(t, translatorTest.LoadBundleContent(defaultLocale, map[string]any{
+		"coins": map[string]any{"$plural": "cardinal", "one": "{{ .count }} coin", "other": "{{ .count }} coins"},
+		"empty": map[string]any{"$plural": "cardinal", "zero": "Empty purse", "one": "One coin", "other": []any{"Coins", "Money"}},
+		"bad":   map[string]any{"one": "coin", "few": "coins"},
+		"menu":  map[string]any{"one": "Option one", "other": "Other"},
+	}))
+	assert.NoError(t, translatorTest.LoadBundleContent(discordgo.Polish, map[string]any{
+		"coins": map[string]any{
+			"$plural": "cardinal",
+			"one":     "{{ .count }} moneta",
+			"few":     "{{ .count }} monety",
+			"many":    "{{ .count }} monet",
+			"other":   "{{ .count }} monety",
+		},
+	}))
+
+	// Cardinal rules of the requested locale
+	assert.Equal(t, "1 coin", translatorTest.GetPlural(defaultLocale, "coins", 1, nil))
+	assert.Equal(t, "5 coins", translatorTest.GetPlural(defaultLocale, "coins", 5, Vars{}))
+	assert.Equal(t, "1 moneta", translatorTest.GetPlural(discordgo.Polish, "coins", 1, nil))
+	assert.Equal(t, "3 monety", translatorTest.GetPlural(discordgo.Polish, "coins", 3, nil))
+	assert.Equal(t, "25 monet", translatorTest.GetPlural(discordgo.Polish, "coins", 25, nil))
+	assert.Equal(t, "1.5 monety", translatorTest.GetPlural(discordgo.Polish, "coins", 1.5, nil))
+
+	// Count variable provided by the caller is kept
+	assert.Equal(t, "many coins", translatorTest.GetPlural(defaultLocale, "coins", 2, Vars{"count": "many"}))
+
+	// Fallback: plural rules of the bundle locale
+	assert.Equal(t, "1 coin", translatorTest.GetPlural(discordgo.Russian, "coins", 1, nil))
+	assert.Equal(t, "21 coins", translatorTest.GetPlural(discordgo.Russian, "coins", 21, nil))
+
+	// Zero form, other form used when the category is not provided
+	assert.Equal(t, "Empty purse", translatorTest.GetPlural(defaultLocale, "empty", 0, nil))
+	assert.Equal(t, "One coin", translatorTest.GetPlural(defaultLocale, "empty", 1, nil))
+	assert.Contains(t, []string{"Coins", "Money"}, translatorTest.GetPlural(defaultLocale, "empty", 2, nil))
+	assert.Equal(t, []string{"Coins", "Money"}, translatorTest.GetArray(defaultLocale, "empty", Vars{"count": 10}))
+
+	// Get relies on the count variable, other form without it
+	assert.Equal(t, "25 monet", translatorTest.Get(discordgo.Polish, "coins", Vars{"count": 25}))
+	assert.Equal(t, "{{ .count }} monety", translatorTest.Get(discordgo.Polish, "coins", nil))
+	assert.Equal(t, "2 monety", (*translatorTest.GetLocalizations("coins", Vars{"count": 2}))[discordgo.Polish])
+
+	// Objects not declaring their plural type are nested keys
+	assert.Equal(t, "coins", translatorTest.Get(defaultLocale, "bad.few", nil))
+	assert.Equal(t, "Other", translatorTest.Get(defaultLocale, "menu.other", nil))
+	assert.Equal(t, "Option one", translatorTest.GetPlural(defaultLocale, "menu.one", 5, nil))
+
+	// Plural objects are rejected at load time when invalid
+	assert.ErrorContains(t, translatorTest.LoadBundleContent(defaultLocale, map[string]any{
+		"coins": map[string]any{"$plural": "cardinal", "one": "coin"},
+	}), "does not declare the 'other' form")
+
+	// Invalid count returns the key
+	assert.Equal(t, "coins", translatorTest.GetPlural(defaultLocale, "coins", "a lot", nil))
+
+	// Non plural keys ignore count
+	assert.Equal(t, "coin", translatorTest.GetPlural(defaultLocale, "bad.one", 5, nil))
+}
+
+// Test getting ordinal translations
+func TestGetPluralOrdinal(t *testing.T) {
+	setUp()
+	defer tearDown()
+
+	rank := map[string]any{
+		"$plural": "ordinal",
+		"one":     "{{ .count }}st",
+		"two":     "{{ .count }}nd",
+		"few":     "{{ .count }}rd",
+		"other":   "{{ .count }}th",
+	}
+	assert.NoError(t, translatorTest.LoadBundleContent(defaultLocale, map[string]any{"rank": rank}))
+	assert.NoError(t, translatorTest.LoadBundleContent(discordgo.French, map[string]any{
+		"rank": map[string]any{"$plural": "ordinal", "one": "{{ .count }}er", "other": "{{ .count }}e"},
+	}))
+
+	assert.Equal(t, "1st", translatorTest.GetPlural(defaultLocale, "rank", 1, nil))
+	assert.Equal(t, "2nd", translatorTest.GetPlural(defaultLocale, "rank", 2, nil))
+	assert.Equal(t, "23rd", translatorTest.GetPlural(defaultLocale, "rank", 23, nil))
+	assert.Equal(t, "11th", translatorTest.GetPlural(defaultLocale, "rank", 11, nil))
+	assert.Equal(t, "1er", translatorTest.GetPlural(discordgo.French, "rank", 1, nil))
+	assert.Equal(t, "2e", translatorTest.Get(discordgo.French, "rank", Vars{"count": 2}))
+
+	// Fallback: ordinal rules of the bundle locale
+	assert.Equal(t, "22nd", translatorTest.GetPlural(discordgo.German, "rank", 22, nil))
+
+	// Explicit cardinal type
+	assert.NoError(t, translatorTest.LoadBundleContent(discordgo.German, map[string]any{
+		"rank": map[string]any{"$plural": "cardinal", "one": "{{ .count }} Platz", "other": "{{ .count }} Plätze"},
+	}))
+	assert.Equal(t, "2 Plätze", translatorTest.GetPlural(discordgo.German, "rank", 2, nil))
+
+	// Unknown type is rejected at load time
+	assert.Error(t, translatorTest.LoadBundleContent(discordgo.Italian, map[string]any{
+		"rank": map[string]any{"$plural": "ordinals", "other": "{{ .count }}°"},
+	}))
+}
+
+// Test translating ICU messages
+func TestGetICU(t *testing.T) {
+	setUp()
+	defer tearDown()
+
+	// Syntax chosen per bundle
+	assert.NoError(t, translatorTest.LoadBundleContent(defaultLocale, map[string]any{
+		"$syntax": "icu",
+		"hello":   "Hello {anyone}!",
+		"items":   "{count, plural, one {# item} other {# items}}",
+		"plain":   "Hello world!",
+		"quoted":  "It''s '{'plain'}'",
+		"rank":    []any{"{rank, selectordinal, one {#st} two {#nd} few {#rd} other {#th}}"},
+	}))
+	assert.NoError(t, translatorTest.LoadBundleContent(discordgo.French, map[string]any{
+		"hello": "Bonjour {{ .anyone }} !",
+	}))
+	assert.Error(t, translatorTest.LoadBundleContent(discordgo.German, map[string]any{
+		"$syntax": "icu",
+		"items":   "{count, plural, one {# item}}",
+	}))
+	assert.Error(t, translatorTest.LoadBundleContent(discordgo.German, map[string]any{"$syntax": "unknown", "hi": "hi"}))
+	assert.Error(t, translatorTest.LoadBundleContent(discordgo.German, map[string]any{"$syntax": 1, "hi": "hi"}))
+
+	assert.Equal(t, "Hello Nick!", translatorTest.Get(defaultLocale, "hello", Vars{"anyone": "Nick"}))
+	assert.Equal(t, "Bonjour Nick !", translatorTest.Get(discordgo.French, "hello", Vars{"anyone": "Nick"}))
+	assert.Equal(t, "Hello {anyone}!", translatorTest.Get(defaultLocale, "hello", nil))
+	assert.Equal(t, "Hello world!", translatorTest.Get(defaultLocale, "plain", nil))
+	assert.Equal(t, "It's {plain}", translatorTest.Get(defaultLocale, "quoted", nil))
+	assert.Equal(t, "5 items", translatorTest.GetPlural(defaultLocale, "items", 5, nil))
+	assert.Equal(t, []string{"22nd"}, translatorTest.GetArray(defaultLocale, "rank", Vars{"rank": 22}))
+	assert.Equal(t, "$syntax", translatorTest.Get(defaultLocale, "$syntax", nil))
+
+	// Missing variable returns the key
+	assert.Equal(t, "hello", translatorTest.Get(defaultLocale, "hello", Vars{}))
+
+	// Syntax chosen per translator
+	translatorTest.SetSyntax(SyntaxICU)
+	assert.NoError(t, translatorTest.LoadBundleContent(discordgo.German, map[string]any{
+		"hello": "Hallo {anyone}!",
+		"mixed": map[string]any{"$plural": "cardinal", "one": "{count} Münze", "other": "{count} Münzen"},
+	}))
+	assert.Equal(t, "Hallo Nick!", translatorTest.Get(discordgo.German, "hello", Vars{"anyone": "Nick"}))
+	assert.Equal(t, "2 Münzen", translatorTest.GetPlural(discordgo.German, "mixed", 2, nil))
+	assert.Error(t, translatorTest.LoadBundle(discordgo.Dutch, translatorNominalCase1))
+
+	// Bundle declaration takes precedence
+	assert.NoError(t, translatorTest.LoadBundleContent(discordgo.Dutch, map[string]any{
+		"$syntax": "template",
+		"hello":   "Hallo {{ .anyone }}!",
+	}))
+	assert.Equal(t, "Hallo Nick!", translatorTest.Get(discordgo.Dutch, "hello", Vars{"anyone": "Nick"}))
+}
+
+// Test template functions are bound to the requested locale
+func TestGetLocalizedFormatting(t *testing.T) {
+	setUp()
+	defer tearDown()
+
+	assert.NoError(t, translatorTest.LoadBundleContent(defaultLocale, map[string]any{
+		"balance": "Balance: {{ currency \"EUR\" .amount }} ({{ percent .ratio }})",
+		"members": "{{ number .count }} members",
+	}))
+	assert.NoError(t, translatorTest.LoadBundleContent(discordgo.German, map[string]any{
+		"members": "{{ number .count }} Mitglieder",
+	}))
+	assert.Error(t, translatorTest.LoadBundleContent(discordgo.Italian, map[string]any{
+		"members": "{{ unknown .count }} membri",
+	}))
+
+	variables := Vars{"amount": 1234.5, "ratio": 0.5, "count": 12345}
+	assert.Equal(t, "Balance: €1,234.50 (50%)", translatorTest.Get(defaultLocale, "balance", variables))
+	assert.Equal(t, "12.345 Mitglieder", translatorTest.Get(discordgo.German, "members", variables))
+	assert.Equal(t, []string{"12,345 members"}, translatorTest.GetArray(defaultLocale, "members", variables))
+
+	// Fallback: formatted in the requested locale
+	assert.Equal(t, "Balance: 1\u202f234,50\u00a0€ (50\u202f%)", translatorTest.Get(discordgo.French, "balance", variables))
+	assert.Equal(t, "12,345 members", translatorTest.Get(discordgo.Japanese, "members", variables))
+
+	// Dates, times and durations
+	assert.NoError(t, translatorTest.LoadBundleContent(discordgo.French, map[string]any{
+		"cooldown": "Réessaie dans {{ duration .cooldown }}, le {{ date .end \"long\" }} à {{ time .end \"short\" }}",
+	}))
+	assert.Equal(t, "Réessaie dans 2 heures 5 minutes, le 5 mars 2024 à 14:07", translatorTest.Get(discordgo.French,
+		"cooldown", Vars{"cooldown": 2*time.Hour + 5*time.Minute, "end": time.Date(2024, time.March, 5, 14, 7, 0, 0, time.UTC)}))
+
+	// Discord timestamps, rendered by clients
+	assert.NoError(t, translatorTest.LoadBundleContent(discordgo.German, map[string]any{
+		"ends": "Endet {{ discordTime .end \"R\" }}",
+	}))
+	assert.Equal(t, "Endet <t:1709647620:R>", translatorTest.Get(discordgo.German, "ends",
+		Vars{"end": time.Date(2024, time.March, 5, 14, 7, 0, 0, time.UTC)}))
+
+	// Mentions
+	assert.NoError(t, translatorTest.LoadBundleContent(discordgo.Italian, map[string]any{
+		"welcome": "Benvenuto {{ mention .User }}, leggi {{ channel .Rules }} {{ emoji \"👋\" }}",
+	}))
+	assert.Equal(t, "Benvenuto <@80351110224678912>, leggi <#41771983423143937> 👋", translatorTest.Get(discordgo.Italian,
+		"welcome", Vars{"User": &discordgo.User{ID: "80351110224678912"}, "Rules": "41771983423143937"}))
+
+	// Templates bound to each locale are cached
+	templates := translatorTest.state.Load().translations[defaultLocale]["balance"].messages[0].templates.Load()
+	assert.Len(t, *templates, 2)
+}
+
+// Test getting translations selected by a variable
+func TestGetSelect(t *testing.T) {
+	setUp()
+	defer tearDown()
+
+	assert.NoError(t, translatorTest.LoadBundleContent(discordgo.French, map[string]any{
+		"welcome": map[string]any{
+			selectKey: "gender",
+			"female":  "Bienvenue {{ .name }}, tu es inscrite",
+			"male":    "Bienvenue {{ .name }}, tu es inscrit",
+			"other":   "Bienvenue {{ .name }}, ton inscription est validée",
+		},
+		"banned": map[string]any{
+			selectKey: "gender",
+			"female":  map[string]any{"$plural": "cardinal", "one": "Elle a été bannie {{ .count }} fois", "other": "Elle a été bannie {{ .count }} fois"},
+			"other":   map[string]any{"$plural": "cardinal", "one": "Il a été banni {{ .count }} fois", "other": "Il a été banni {{ .count }} fois"},
+		},
+		"greetings": map[string]any{selectKey: "gender", "other": []any{"Salut", "Coucou"}},
+	}))
+	assert.NoError(t, translatorTest.LoadBundleContent(defaultLocale, map[string]any{
+		"welcome": map[string]any{selectKey: "gender", "other": "Welcome {{ .name }}"},
+	}))
+
+	assert.Equal(t, "Bienvenue Alice, tu es inscrite",
+		translatorTest.Get(discordgo.French, "welcome", Vars{"name": "Alice", "gender": "female"}))
+	assert.Equal(t, "Bienvenue Bob, tu es inscrit",
+		translatorTest.Get(discordgo.French, "welcome", Vars{"name": "Bob", "gender": "male"}))
+	assert.Equal(t, "Bienvenue Sam, ton inscription est validée",
+		translatorTest.Get(discordgo.French, "welcome", Vars{"name": "Sam", "gender": "unknown"}))
+	assert.Equal(t, "Bienvenue Sam, ton inscription est validée",
+		translatorTest.Get(discordgo.French, "welcome", Vars{"name": "Sam"}))
+	assert.Equal(t, "Elle a été bannie 2 fois",
+		translatorTest.GetPlural(discordgo.French, "banned", 2, Vars{"gender": "female"}))
+	assert.Equal(t, "Il a été banni 1 fois", translatorTest.GetPlural(discordgo.French, "banned", 1, nil))
+	assert.Equal(t, []string{"Salut", "Coucou"}, translatorTest.GetArray(discordgo.French, "greetings", nil))
+
+	localizations := translatorTest.GetLocalizations("welcome", Vars{"name": "Alice", "gender": "female"})
+	assert.Equal(t, "Bienvenue Alice, tu es inscrite", (*localizations)[discordgo.French])
+	assert.Equal(t, "Welcome Alice", (*localizations)[defaultLocale])
+
+	// The other case is mandatory
+	assert.Error(t, translatorTest.LoadBundleContent(discordgo.German, map[string]any{
+		"welcome": map[string]any{selectKey: "gender", "female": "Willkommen"},
+	}))
+}
+
+// Test getting translations referencing other keys
+func TestGetReferences(t *testing.T) {
+	setUp()
+	defer tearDown()
+
+	french := map[string]any{
+		"welcome":  "{{ t \"greet\" . }}. {{ t \"footer\" }}",
+		"basket":   "Panier : {{ t \"items\" . }}",
+		"unknown":  "{{ t \"missing\" }}",
+		"loop.fr":  "{{ t \"loop\" }}",
+		"markdown": "{{ .name }} {{ t \"greet\" . }}",
+	}
+	assert.NoError(t, translatorTest.LoadBundleContent(defaultLocale, map[string]any{
+		"bot":    "Gopher",
+		"footer": "Made by {{ t \"bot\" }}",
+		"greet":  "Hello {{ .name }}, I am {{ t \"bot\" }}",
+		"items":  map[string]any{"$plural": "cardinal", "one": "one item", "other": "{{ .count }} items"},
+		"loop":   "{{ t \"loop.fr\" }}",
+	}))
+	assert.NoError(t, translatorTest.LoadBundleContent(discordgo.French, french))
+
+	// Referenced keys are resolved through fallbacks, even without variables
+	assert.Equal(t, "Made by Gopher", translatorTest.Get(discordgo.French, "footer", nil))
+	assert.Equal(t, "Hello Nick, I am Gopher. Made by Gopher",
+		translatorTest.Get(discordgo.French, "welcome", Vars{"name": "Nick"}))
+	assert.Equal(t, "Panier : 3 items", translatorTest.Get(discordgo.French, "basket", Vars{"count": 3}))
+	localizations := translatorTest.GetLocalizations("welcome", Vars{"name": "Nick"})
+	assert.Equal(t, "Hello Nick, I am Gopher. Made by Gopher", (*localizations)[discordgo.French])
+
+	// Missing keys and cycles through fallbacks return the key
+	assert.Equal(t, "unknown", translatorTest.Get(discordgo.French, "unknown", nil))
+	assert.Equal(t, "loop", translatorTest.Get(discordgo.French, "loop", nil))
+
+	// Nested translations are not escaped twice
+	translatorTest.SetMarkdownEscaping(true)
+	assert.NoError(t, translatorTest.LoadBundleContent(defaultLocale, map[string]any{"greet": "Hello **{{ .name }}**"}))
+	assert.NoError(t, translatorTest.LoadBundleContent(discordgo.French, french))
+	assert.Equal(t, "\\*nick\\* Hello **\\*nick\\***",
+		translatorTest.Get(discordgo.French, "markdown", Vars{"name": "*nick*"}))
+}
+
 // Test getting arrays of translations
 func TestGetArray(t *testing.T) {
 	setUp()
@@ -228,6 +1159,38 @@ func TestGetArray(t *testing.T) {
 
 	// Nonexistent key returns array with key
 	assert.Equal(t, 1, len(translatorTest.GetArray(discordgo.Dutch, "no_exist", nil)))
+
+	// Default locale fallback
+	assert.NoError(t, translatorTest.LoadBundle(defaultLocale, translatorNominalCase2))
+	assert.Equal(t, []string{"containing", "less", "variables"}, translatorTest.GetArray(discordgo.Dutch, "with.a.file", nil))
+	assert.Equal(t, []string{"see you"}, translatorTest.GetArray(discordgo.French, "bye", nil))
+	assert.Equal(t, []string{"no_exist"}, translatorTest.GetArray(discordgo.French, "no_exist", nil))
+}
+
+// Test bundles are never modified by reads
+func TestGetArrayImmutability(t *testing.T) {
+	setUp()
+	defer tearDown()
+
+	// Same bundle shared by two locales through the bundle cache
+	assert.NoError(t, translatorTest.LoadBundle(discordgo.French, translatorNominalCase1))
+	assert.NoError(t, translatorTest.LoadBundle(discordgo.German, translatorNominalCase1))
+
+	// Rendering with variables does not replace the templates
+	assert.Equal(t, []string{"file", "! Nick"}, translatorTest.GetArray(discordgo.French, "config", Vars{"Author": "Nick"}))
+	assert.Equal(t, []string{"file", "! John"}, translatorTest.GetArray(discordgo.French, "config", Vars{"Author": "John"}))
+	assert.Equal(t, []string{"file", "! Jane"}, translatorTest.GetArray(discordgo.German, "config", Vars{"Author": "Jane"}))
+	assert.Equal(t, []string{"file", "! {{ .Author }}"}, translatorTest.GetArray(discordgo.French, "config", nil))
+
+	// Mutating returned slices does not alter the bundle, with or without variables
+	translations := translatorTest.GetArray(discordgo.French, "the", nil)
+	translations[0] = "mutated"
+	translations = translatorTest.GetArray(discordgo.French, "config", Vars{"Author": "Nick"})
+	translations[1] = "mutated"
+	assert.Equal(t, []string{"elements", "we"}, translatorTest.GetArray(discordgo.German, "the", nil))
+	assert.Equal(t, []string{"file", "! {{ .Author }}"}, translatorTest.GetArray(discordgo.German, "config", nil))
+	assert.Equal(t, "elements", translatorTest.state.Load().translations[discordgo.French]["the"].messages[0].raw)
+	assert.Equal(t, "! {{ .Author }}", translatorTest.state.Load().translations[discordgo.French]["config"].messages[1].raw)
 }
 
 // Test getting default locale translations
@@ -241,7 +1204,6 @@ func TestGetDefault(t *testing.T) {
 	assert.Equal(t, "does_not_exist", translatorTest.GetDefault("does_not_exist", nil))
 	assert.Equal(t, "is a {{ .Test }}", translatorTest.GetDefault("this", nil))
 	assert.Equal(t, "is a test :)", translatorTest.GetDefault("this", Vars{"Test": "test :)"}))
-	assert.Equal(t, "parse2", translatorTest.GetDefault("parse2", Vars{}))
 	assert.Equal(t, "this", translatorTest.GetDefault("this", Vars{}))
 }
 
@@ -256,8 +1218,8 @@ func TestGetDefaultArray(t *testing.T) {
 	assert.NoError(t, translatorTest.LoadBundle(defaultLocale, translatorNominalCase2))
 	assert.Equal(t, []string{"see you"}, translatorTest.GetDefaultArray("bye", nil))
 
-	// Malformed template: return key in array
-	assert.Equal(t, 1, len(translatorTest.GetDefaultArray("parse2", Vars{})))
+	// Missing variable: return key in array
+	assert.Equal(t, []string{"this"}, translatorTest.GetDefaultArray("this", Vars{}))
 }
 
 // Test getting all localizations for a key
@@ -273,9 +1235,10 @@ func TestGetLocalizations(t *testing.T) {
 	assert.NoError(t, translatorTest.LoadBundle(discordgo.Dutch, translatorNominalCase1))
 	assert.NoError(t, translatorTest.LoadBundle(defaultLocale, translatorNominalCase2))
 
-	// Key present: all locales with variable provided
+	// Key present: all locales with variable provided, en-GB being localized through its fallback chain
 	assert.NotNil(t, translatorTest.GetLocalizations("hi", Vars{"Test": "foo"}))
-	assert.Equal(t, 2, len(*translatorTest.GetLocalizations("hi", Vars{"Test": "foo"})))
+	assert.Equal(t, 3, len(*translatorTest.GetLocalizations("hi", Vars{"Test": "foo"})))
+	assert.Equal(t, "see you", (*translatorTest.GetLocalizations("bye", nil))[discordgo.EnglishGB])
 
 	// Missing variable: returns key
 	assert.NotNil(t, translatorTest.GetLocalizations("hi", Vars{}))
@@ -283,3 +1246,102 @@ func TestGetLocalizations(t *testing.T) {
 	// Key absent in bundles: returns empty or partial map
 	assert.NotNil(t, translatorTest.GetLocalizations("inconnue", Vars{}))
 }
+
+// Test concurrent loads, reloads and reads, meant to be run with the race detector
+func TestConcurrency(t *testing.T) {
+	setUp()
+	defer tearDown()
+
+	const goroutines = 8
+	const iterations = 50
+
+	assert.NoError(t, translatorTest.LoadBundle(defaultLocale, translatorNominalCase2))
+
+	var wg sync.WaitGroup
+	for range goroutines {
+		wg.Add(2)
+
+		// Writers: load, reload and reconfigure while reading
+		go func() {
+			defer wg.Done()
+			for i := range iterations {
+				assert.NoError(t, translatorTest.LoadBundle(discordgo.French, translatorNominalCase1))
+				assert.NoError(t, translatorTest.LoadBundle(discordgo.French, translatorNominalCase2))
+				assert.NoError(t, translatorTest.LoadBundleContent(discordgo.German, content2Map))
+				translatorTest.SetFallbacks(discordgo.PortugueseBR, discordgo.French)
+				if i%2 == 0 {
+					translatorTest.SetDefault(discordgo.EnglishUS)
+				}
+			}
+		}()
+
+		// Readers: every reading method must always see a consistent snapshot
+		go func() {
+			defer wg.Done()
+			for range iterations {
+				assert.NotEmpty(t, translatorTest.Get(discordgo.French, "hi", Vars{"Test": "test"}))
+				assert.NotEmpty(t, translatorTest.Get(discordgo.PortugueseBR, "bye", nil))
+				assert.NotEmpty(t, translatorTest.GetArray(discordgo.French, "config", Vars{"Author": "me"}))
+				assert.NotEmpty(t, translatorTest.GetArray(discordgo.French, "this", Vars{"Test": "test"}))
+				assert.Equal(t, "see you", translatorTest.GetDefault("bye", nil))
+				assert.NotEmpty(t, translatorTest.GetDefaultArray("with.a.file", nil))
+				assert.NotNil(t, translatorTest.GetLocalizations("bye", nil))
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 3, len(translatorTest.state.Load().translations[discordgo.French]))
+	assert.Equal(t, []string{"is a {{ .Test }}"}, translatorTest.GetArray(discordgo.French, "this", nil))
+	assert.Equal(t, []string{"containing", "less", "variables"},
+		translatorTest.GetArray(discordgo.German, "with.a.file", nil))
+}
+
+const benchmarkRaw = "Hello {{ .anyone }}, welcome to {{ .guild }}!"
+
+// Benchmark translating a template with variables, compiled at load time
+func BenchmarkGet(b *testing.B) {
+	translator := NewTranslator(&logger.DummyLogger{})
+	if err := translator.LoadBundleContent(defaultLocale, map[string]any{"hello": benchmarkRaw}); err != nil {
+		b.Fatal(err)
+	}
+	variables := Vars{"anyone": "Nick", "guild": "Gophers"}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		translator.Get(defaultLocale, "hello", variables)
+	}
+}
+
+// Benchmark translating a template with variables, parsed on every call as Get used to do
+func BenchmarkGetParsePerCall(b *testing.B) {
+	variables := Vars{"anyone": "Nick", "guild": "Gophers"}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		t, err := template.New("").Delims(leftDelim, rightDelim).Option(executionPolicy).Parse(benchmarkRaw)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		var buf bytes.Buffer
+		if err = t.Execute(&buf, variables); err != nil {
+			b.Fatal(err)
+		}
+		_ = buf.String()
+	}
+}
+
+// Benchmark translating a plain string, which must not allocate
+func BenchmarkGetPlain(b *testing.B) {
+	translator := NewTranslator(&logger.DummyLogger{})
+	if err := translator.LoadBundleContent(defaultLocale, map[string]any{"hello": "Hello world!"}); err != nil {
+		b.Fatal(err)
+	}
+	variables := Vars{"anyone": "Nick"}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		translator.Get(defaultLocale, "hello", variables)
+	}
+}
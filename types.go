@@ -1,7 +1,11 @@
 package discordgoi18n
 
 import (
+	"io"
 	"io/fs"
+	"sync"
+	"sync/atomic"
+	"text/template"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/kstoums/discordgo-i18n/logger"
@@ -11,38 +15,227 @@ import (
 // This type only exists to be less verbose.
 type Vars map[string]any
 
+// Syntax is the syntax bundle values are written in.
+type Syntax string
+
+const (
+	// SyntaxTemplate parses bundle values with text/template, this is the default syntax.
+	SyntaxTemplate Syntax = "template"
+	// SyntaxICU parses bundle values as ICU MessageFormat messages.
+	SyntaxICU Syntax = "icu"
+)
+
+// Format is the format bundle files are written in.
+type Format string
+
+const (
+	// FormatJSON decodes bundle files as JSON, this is the default format.
+	FormatJSON Format = "json"
+	// FormatYAML decodes bundle files as YAML, the format of .yaml and .yml files.
+	FormatYAML Format = "yaml"
+	// FormatTOML decodes bundle files as TOML, the format of .toml files.
+	FormatTOML Format = "toml"
+)
+
+// XLIFFVersion is the version of the XLIFF files bundles are exported to.
+type XLIFFVersion string
+
+const (
+	// XLIFFVersion12 exports bundles as XLIFF 1.2 files.
+	XLIFFVersion12 XLIFFVersion = "1.2"
+	// XLIFFVersion20 exports bundles as XLIFF 2.0 files.
+	XLIFFVersion20 XLIFFVersion = "2.0"
+)
+
+// LoadMode is the way a loaded bundle is stored along with the bundles already loaded for
+// its locale.
+type LoadMode string
+
+const (
+	// LoadModeReplace replaces the bundles of the locale by the loaded one, this is the default mode.
+	LoadModeReplace LoadMode = "replace"
+	// LoadModeMerge adds the keys of the loaded bundle to the bundles of the locale.
+	LoadModeMerge LoadMode = "merge"
+)
+
+// ConflictPolicy is the way keys declared by several bundles of a locale are handled when
+// bundles are merged.
+type ConflictPolicy string
+
+const (
+	// ConflictError rejects the bundle declaring a key already loaded, this is the default policy.
+	ConflictError ConflictPolicy = "error"
+	// ConflictFirstWins keeps the translations of the bundle loaded first.
+	ConflictFirstWins ConflictPolicy = "first"
+	// ConflictLastWins overwrites translations with the ones of the bundle loaded last.
+	ConflictLastWins ConflictPolicy = "last"
+)
+
+// LocaleValidation is the way locales bundles are loaded for are checked against the locales
+// supported by Discord.
+type LocaleValidation string
+
+const (
+	// LocaleValidationLenient loads bundles of unsupported locales with a warning, this is the
+	// default validation.
+	LocaleValidationLenient LocaleValidation = "lenient"
+	// LocaleValidationStrict rejects bundles of unsupported locales.
+	LocaleValidationStrict LocaleValidation = "strict"
+)
+
+// Markdown is a text already formatted for Discord: it is injected in messages as is, even
+// when Markdown escaping or mentions neutralization is enabled.
+type Markdown string
+
 type Translator interface {
-	SetDefault(locale discordgo.Locale) // Defined in constructor
+	SetDefault(locale discordgo.Locale)              // Defined in constructor
+	SetSyntax(syntax Syntax)                         // Defined in constructor
+	SetMarkdownEscaping(enabled bool)                // Defined in constructor
+	SetMentionNeutralization(enabled bool)           // Defined in constructor
+	SetLoadMode(mode LoadMode)                       // Defined in constructor
+	SetConflictPolicy(policy ConflictPolicy)         // Defined in constructor
+	SetLocaleValidation(validation LocaleValidation) // Defined in constructor
+	AddFuncs(funcs template.FuncMap) error
+	AddLocaleFuncs(locale discordgo.Locale, funcs template.FuncMap) error
+	SetFallbacks(locale discordgo.Locale, fallbacks ...discordgo.Locale)
 	LoadBundle(locale discordgo.Locale, path string) error
 	LoadBundleFS(locale discordgo.Locale, fs fs.FS, path string) error
+	LoadBundleFormat(locale discordgo.Locale, path string, format Format) error
+	LoadBundleFSFormat(locale discordgo.Locale, fs fs.FS, path string, format Format) error
 	LoadBundleContent(locale discordgo.Locale, content map[string]any) error
+	LoadDir(dir string) error
+	LoadDirFS(fs fs.FS, dir string) error
+	LoadGettext(locale discordgo.Locale, path string, fuzzy bool) error
+	LoadGettextFS(locale discordgo.Locale, fs fs.FS, path string, fuzzy bool) error
+	LoadXLIFF(locale discordgo.Locale, path string) error
+	LoadXLIFFFS(locale discordgo.Locale, fs fs.FS, path string) error
+	ExportXLIFF(w io.Writer, source, target discordgo.Locale, version XLIFFVersion) error
+	GetOverwrittenKeys(locale discordgo.Locale) []string
 	Get(locale discordgo.Locale, key string, values Vars) string
 	GetArray(locale discordgo.Locale, key string, values Vars) []string
+	GetPlural(locale discordgo.Locale, key string, count any, values Vars) string
 	GetDefault(key string, values Vars) string
 	GetDefaultArray(key string, values Vars) []string
 	GetLocalizations(key string, variables Vars) *map[discordgo.Locale]string
 }
 
 type translatorImpl struct {
-	defaultLocale discordgo.Locale
-	translations  map[discordgo.Locale]bundle
-	loadedBundles map[string]bundle
-	logger        logger.Logger
+	state  atomic.Pointer[translatorState]
+	mutex  sync.Mutex
+	logger logger.Logger
+}
+
+// translatorState is an immutable snapshot of the translator configuration and bundles.
+// It is never modified once published, a new snapshot replaces it on every write.
+type translatorState struct {
+	defaultLocale      discordgo.Locale
+	fallbacks          map[discordgo.Locale][]discordgo.Locale
+	syntax             Syntax
+	escapeMarkdown     bool
+	neutralizeMentions bool
+	funcs              customFuncs
+	loadMode           LoadMode
+	conflictPolicy     ConflictPolicy
+	localeValidation   LocaleValidation
+	// optionsVersion changes with the options bundles are compiled with.
+	optionsVersion  uint64
+	translations    map[discordgo.Locale]bundle
+	layers          map[discordgo.Locale][]bundleLayer
+	overwrittenKeys map[discordgo.Locale][]string
+	loadedBundles   map[string]bundleLayer
+}
+
+// compileOptions are the translator settings bundles are compiled with.
+type compileOptions struct {
+	syntax     Syntax
+	sanitizer  sanitizer
+	funcs      customFuncs
+	references referenceRenderer
+	partials   *template.Template
 }
 
 type translatorMock struct {
-	SetDefaultFunc        func(locale discordgo.Locale)
-	LoadBundleFunc        func(locale discordgo.Locale, path string) error
-	LoadBundleFSFunc      func(locale discordgo.Locale, fs fs.FS, path string) error
-	LoadBundleContentFunc func(locale discordgo.Locale, content map[string]any) error
-	GetFunc               func(locale discordgo.Locale, key string, values Vars) string
-	GetArrayFunc          func(locale discordgo.Locale, key string, values Vars) []string
-	GetDefaultFunc        func(key string, values Vars) string
-	GetDefaultArrayFunc   func(key string, values Vars) []string
-	GetLocalizationsFunc  func(key string, variables Vars) *map[discordgo.Locale]string
-}
-
-type bundle map[string][]string
+	SetDefaultFunc               func(locale discordgo.Locale)
+	SetFallbacksFunc             func(locale discordgo.Locale, fallbacks ...discordgo.Locale)
+	SetSyntaxFunc                func(syntax Syntax)
+	SetMarkdownEscapingFunc      func(enabled bool)
+	SetMentionNeutralizationFunc func(enabled bool)
+	SetLoadModeFunc              func(mode LoadMode)
+	SetConflictPolicyFunc        func(policy ConflictPolicy)
+	SetLocaleValidationFunc      func(validation LocaleValidation)
+	AddFuncsFunc                 func(funcs template.FuncMap) error
+	AddLocaleFuncsFunc           func(locale discordgo.Locale, funcs template.FuncMap) error
+	LoadBundleFunc               func(locale discordgo.Locale, path string) error
+	LoadBundleFSFunc             func(locale discordgo.Locale, fs fs.FS, path string) error
+	LoadBundleFormatFunc         func(locale discordgo.Locale, path string, format Format) error
+	LoadBundleFSFormatFunc       func(locale discordgo.Locale, fs fs.FS, path string, format Format) error
+	LoadBundleContentFunc        func(locale discordgo.Locale, content map[string]any) error
+	LoadDirFunc                  func(dir string) error
+	LoadDirFSFunc                func(fs fs.FS, dir string) error
+	LoadGettextFunc              func(locale discordgo.Locale, path string, fuzzy bool) error
+	LoadGettextFSFunc            func(locale discordgo.Locale, fs fs.FS, path string, fuzzy bool) error
+	LoadXLIFFFunc                func(locale discordgo.Locale, path string) error
+	LoadXLIFFFSFunc              func(locale discordgo.Locale, fs fs.FS, path string) error
+	ExportXLIFFFunc              func(w io.Writer, source, target discordgo.Locale, version XLIFFVersion) error
+	GetOverwrittenKeysFunc       func(locale discordgo.Locale) []string
+	GetFunc                      func(locale discordgo.Locale, key string, values Vars) string
+	GetArrayFunc                 func(locale discordgo.Locale, key string, values Vars) []string
+	GetPluralFunc                func(locale discordgo.Locale, key string, count any, values Vars) string
+	GetDefaultFunc               func(key string, values Vars) string
+	GetDefaultArrayFunc          func(key string, values Vars) []string
+	GetLocalizationsFunc         func(key string, variables Vars) *map[discordgo.Locale]string
+}
+
+type bundle map[string]*entry
+
+// bundleLayer is a bundle loaded from cachePath, merged with the other layers of its locale.
+// The bundle is compiled from content with syntax, the options of version and the partials and
+// constants shared by the layers of its locale, so that it can be compiled again once they change.
+// Layers read from directories are only compiled once stored, their bundle being nil until then.
+type bundleLayer struct {
+	cachePath string
+	content   map[string]any
+	bundle    bundle
+	syntax    Syntax
+	version   uint64
+	shared    sharedTemplates
+}
+
+// sharedTemplates are the raw partials and constants declared by the layers of a locale, which
+// every message of the locale can include.
+type sharedTemplates struct {
+	partials  map[string]any
+	constants map[string]any
+}
+
+// entry is a compiled bundle key: either messages picked randomly, plural forms selected
+// with ordinal rules instead of cardinal ones when ordinal is set, or cases selected by the
+// value of the selector variable. note is left to translators through notesKey.
+type entry struct {
+	messages []*message
+	plurals  map[pluralCategory][]*message
+	ordinal  bool
+	selector string
+	cases    map[string]*entry
+	note     string
+}
+
+// message is a compiled bundle value, either as template or as ICU message depending on
+// the bundle syntax; both are nil when raw does not contain any action. templates caches
+// the template clones bound to each rendering locale and nesting depth, with the built-in
+// and funcs functions; references renders nested translations and nested tells whether raw
+// references other keys or partials. Templates sanitize variables once compiled while ICU
+// messages rely on sanitizer when formatted.
+type message struct {
+	raw        string
+	template   *template.Template
+	templates  atomic.Pointer[map[templateKey]*template.Template]
+	funcs      customFuncs
+	references referenceRenderer
+	nested     bool
+	icu        icuMessage
+	sanitizer  sanitizer
+}
 
 type source string
 
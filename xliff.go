@@ -0,0 +1,679 @@
+package discordgoi18n
+
+import (
+	"cmp"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	// notesKey declares the notes left to translators, by key.
+	notesKey = "$notes"
+
+	xliff12Namespace = "urn:oasis:names:tc:xliff:document:1.2"
+	xliff20Namespace = "urn:oasis:names:tc:xliff:document:2.0"
+	xliffDatatype    = "plaintext"
+	xliffNo          = "no"
+	xliffNoteDelim   = "\n"
+
+	// xliffPathDelim separates the key of a unit from the plural forms and cases leading to
+	// its value, array values being suffixed by their index such as greetings[1].
+	xliffPathDelim    = "#"
+	xliffIndexStart   = "["
+	xliffIndexEnd     = "]"
+	xliffReservedChar = xliffPathDelim + xliffIndexStart
+)
+
+// xliffUnit is a value of a bundle entry identified by its path; units that are not
+// translatable hold the selector and plural type of entries, imported from their source.
+type xliffUnit struct {
+	id           string
+	source       string
+	target       string
+	note         string
+	translatable bool
+}
+
+// xliffStep is a level of a unit path, index being the position of array values or -1.
+type xliffStep struct {
+	name  string
+	index int
+}
+
+// xliffArray holds array values by index until the imported content is complete.
+type xliffArray map[int]any
+
+type xliff12Document struct {
+	XMLName   xml.Name      `xml:"xliff"`
+	Namespace string        `xml:"xmlns,attr,omitempty"`
+	Version   string        `xml:"version,attr"`
+	Files     []xliff12File `xml:"file"`
+}
+
+type xliff12File struct {
+	Original       string        `xml:"original,attr"`
+	SourceLanguage string        `xml:"source-language,attr"`
+	TargetLanguage string        `xml:"target-language,attr,omitempty"`
+	Datatype       string        `xml:"datatype,attr"`
+	Units          []xliff12Unit `xml:"body>trans-unit"`
+}
+
+type xliff12Unit struct {
+	ID        string   `xml:"id,attr"`
+	Resname   string   `xml:"resname,attr,omitempty"`
+	Translate string   `xml:"translate,attr,omitempty"`
+	Source    string   `xml:"source"`
+	Target    string   `xml:"target,omitempty"`
+	Notes     []string `xml:"note"`
+}
+
+type xliff20Document struct {
+	XMLName        xml.Name      `xml:"xliff"`
+	Namespace      string        `xml:"xmlns,attr,omitempty"`
+	Version        string        `xml:"version,attr"`
+	SourceLanguage string        `xml:"srcLang,attr"`
+	TargetLanguage string        `xml:"trgLang,attr,omitempty"`
+	Files          []xliff20File `xml:"file"`
+}
+
+type xliff20File struct {
+	ID    string        `xml:"id,attr"`
+	Units []xliff20Unit `xml:"unit"`
+}
+
+// xliff20Unit is identified by name since unit ids of XLIFF 2.0 must be NMTOKEN.
+type xliff20Unit struct {
+	ID        string           `xml:"id,attr"`
+	Name      string           `xml:"name,attr,omitempty"`
+	Translate string           `xml:"translate,attr,omitempty"`
+	Notes     *xliff20Notes    `xml:"notes"`
+	Segments  []xliff20Segment `xml:"segment"`
+}
+
+type xliff20Notes struct {
+	Notes []string `xml:"note"`
+}
+
+type xliff20Segment struct {
+	Source string `xml:"source"`
+	Target string `xml:"target,omitempty"`
+}
+
+func (translator *translatorImpl) ExportXLIFF(w io.Writer, source, target discordgo.Locale,
+	version XLIFFVersion) error {
+	if parsed, found := parseLocale(string(source)); found {
+		source = parsed
+	}
+	if parsed, found := parseLocale(string(target)); found {
+		target = parsed
+	}
+
+	state := translator.state.Load()
+	sourceBundle, found := state.translations[source]
+	if !found {
+		return fmt.Errorf("no bundle loaded for locale '%s'", source)
+	}
+
+	units, err := xliffUnits(sourceBundle, state.translations[target], target)
+	if err != nil {
+		return err
+	}
+
+	var document any
+	switch version {
+	case XLIFFVersion12:
+		document = newXLIFF12Document(source, target, units)
+	case XLIFFVersion20:
+		document = newXLIFF20Document(source, target, units)
+	default:
+		return fmt.Errorf("unknown XLIFF version '%s'", version)
+	}
+
+	if _, err = io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err = encoder.Encode(document); err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, "\n")
+	return err
+}
+
+func (translator *translatorImpl) LoadXLIFF(locale discordgo.Locale, path string) error {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	return translator.loadXLIFFBuf(locale, path, buf, translator.buildCachePath(path, osSource))
+}
+
+func (translator *translatorImpl) LoadXLIFFFS(locale discordgo.Locale, fsys fs.FS, path string) error {
+	buf, err := fs.ReadFile(fsys, path)
+	if err != nil {
+		return err
+	}
+
+	return translator.loadXLIFFBuf(locale, path, buf, translator.buildCachePath(path, fsSource))
+}
+
+// loadXLIFFBuf converts the targets of the XLIFF file into bundle content, then compiles and
+// stores it like the content given to LoadBundleContent.
+func (translator *translatorImpl) loadXLIFFBuf(locale discordgo.Locale, file string, buf []byte,
+	cachePath string) error {
+	content, err := decodeXLIFF(buf)
+	if err != nil {
+		return fmt.Errorf("cannot decode XLIFF file '%s': %w", file, err)
+	}
+
+	layer, err := translator.newLayer(translator.state.Load(), locale, cachePath, content)
+	if err != nil {
+		return fmt.Errorf("cannot compile XLIFF file '%s': %w", file, err)
+	}
+
+	translator.logger.Debug().Msgf("Bundle '%s' loaded with '%s' content", locale, cachePath)
+	return translator.storeBundle(locale, layer)
+}
+
+// setNotes attaches the notes content declares by key to the entries of the bundle.
+func (bundle bundle) setNotes(content any) error {
+	if content == nil {
+		return nil
+	}
+
+	notes, isMap := content.(map[string]any)
+	if !isMap {
+		return fmt.Errorf("notes '%v' are not an object", content)
+	}
+
+	for key, value := range notes {
+		note, isString := value.(string)
+		if !isString {
+			return fmt.Errorf("note of key '%s' is not a string", key)
+		}
+
+		keyEntry, found := bundle[key]
+		if !found {
+			return fmt.Errorf("note of key '%s' does not match any key", key)
+		}
+		keyEntry.note = note
+	}
+
+	return nil
+}
+
+// content returns the entry as bundle content, the raw of its messages in place of values.
+func (entry *entry) content() any {
+	switch {
+	case entry.cases != nil:
+		content := map[string]any{selectKey: entry.selector}
+		for selectCase, caseEntry := range entry.cases {
+			content[selectCase] = caseEntry.content()
+		}
+		return content
+	case entry.plurals != nil:
+		content := map[string]any{pluralKey: pluralTypeCardinal}
+		if entry.ordinal {
+			content[pluralKey] = pluralTypeOrdinal
+		}
+		for category, messages := range entry.plurals {
+			content[string(category)] = messagesContent(messages)
+		}
+		return content
+	}
+
+	return messagesContent(entry.messages)
+}
+
+func messagesContent(messages []*message) any {
+	if len(messages) == 1 {
+		return messages[0].raw
+	}
+
+	raws := make([]any, 0, len(messages))
+	for _, msg := range messages {
+		raws = append(raws, msg.raw)
+	}
+
+	return raws
+}
+
+// xliffExport collects the units of the entries exported to XLIFF.
+type xliffExport struct {
+	units        []xliffUnit
+	targetLocale discordgo.Locale
+	note         string
+}
+
+// xliffUnits returns a unit per value of the source bundle by key, along with the value of the
+// target bundle sharing its path if any.
+func xliffUnits(source, target bundle, targetLocale discordgo.Locale) ([]xliffUnit, error) {
+	export := &xliffExport{targetLocale: targetLocale}
+	for _, key := range slices.Sorted(maps.Keys(source)) {
+		var targetContent any
+		if targetEntry, found := target[key]; found {
+			targetContent = targetEntry.content()
+		}
+
+		export.note = source[key].note
+		if err := export.addValue("", key, source[key].content(), targetContent); err != nil {
+			return nil, err
+		}
+	}
+
+	return export.units, nil
+}
+
+func (export *xliffExport) addValue(path, name string, source, target any) error {
+	if strings.ContainsAny(name, xliffReservedChar) {
+		return fmt.Errorf("key '%s' cannot be exported to XLIFF since it contains '%s' or '%s'",
+			name, xliffPathDelim, xliffIndexStart)
+	}
+
+	if path != "" {
+		name = path + xliffPathDelim + name
+	}
+
+	switch value := source.(type) {
+	case map[string]any:
+		return export.addObject(name, value, target)
+	case []any:
+		targets, _ := target.([]any)
+		for i, raw := range value {
+			unit := xliffUnit{id: name + xliffIndexStart + strconv.Itoa(i) + xliffIndexEnd, source: fmt.Sprint(raw),
+				note: export.note, translatable: true}
+			if i < len(targets) {
+				unit.target = fmt.Sprint(targets[i])
+			}
+			export.units = append(export.units, unit)
+		}
+	default:
+		unit := xliffUnit{id: name, source: fmt.Sprint(value), note: export.note, translatable: true}
+		if target != nil {
+			unit.target = fmt.Sprint(target)
+		}
+		export.units = append(export.units, unit)
+	}
+
+	return nil
+}
+
+// addObject adds the units of plural forms or cases, preceded by the plural type or selector
+// which are not translatable.
+func (export *xliffExport) addObject(path string, source map[string]any, target any) error {
+	targets, _ := target.(map[string]any)
+	if !isSelectContent(source) && export.targetLocale != "" {
+		source = export.withTargetForms(source)
+	}
+
+	for _, name := range slices.SortedFunc(maps.Keys(source), compareXLIFFNames) {
+		if name == selectKey || name == pluralKey {
+			export.units = append(export.units, xliffUnit{id: path + xliffPathDelim + name,
+				source: fmt.Sprint(source[name]), note: export.note})
+			continue
+		}
+
+		if err := export.addValue(path, name, source[name], targets[name]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// withTargetForms adds the plural forms of the target locale missing from the source ones,
+// initialized with the other form, so that translators provide every form of the target locale.
+func (export *xliffExport) withTargetForms(source map[string]any) map[string]any {
+	rule := cardinalRule(export.targetLocale)
+	if source[pluralKey] == pluralTypeOrdinal {
+		rule = ordinalRule(export.targetLocale)
+	}
+
+	forms := maps.Clone(source)
+	for category := range pluralCategories(rule) {
+		if _, found := forms[string(category)]; !found {
+			forms[string(category)] = source[string(pluralOther)]
+		}
+	}
+
+	return forms
+}
+
+// compareXLIFFNames sorts the selector and plural type first, then plural forms in the CLDR
+// order and cases, the other form or case being last.
+func compareXLIFFNames(a, b string) int {
+	rank := func(name string) int {
+		switch name {
+		case selectKey, pluralKey:
+			return 0
+		case string(pluralZero):
+			return 1
+		case string(pluralOne):
+			return 2
+		case string(pluralTwo):
+			return 3
+		case string(pluralFew):
+			return 4
+		case string(pluralMany):
+			return 5
+		case string(pluralOther):
+			return 7
+		}
+		return 6
+	}
+
+	return cmp.Or(cmp.Compare(rank(a), rank(b)), strings.Compare(a, b))
+}
+
+func newXLIFF12Document(source, target discordgo.Locale, units []xliffUnit) xliff12Document {
+	file := xliff12File{
+		Original:       string(source),
+		SourceLanguage: string(source),
+		TargetLanguage: string(target),
+		Datatype:       xliffDatatype,
+		Units:          make([]xliff12Unit, 0, len(units)),
+	}
+	for _, unit := range units {
+		xmlUnit := xliff12Unit{ID: unit.id, Source: unit.source, Target: unit.target}
+		if !unit.translatable {
+			xmlUnit.Translate = xliffNo
+		}
+		if unit.note != "" {
+			xmlUnit.Notes = []string{unit.note}
+		}
+		file.Units = append(file.Units, xmlUnit)
+	}
+
+	return xliff12Document{
+		Namespace: xliff12Namespace,
+		Version:   string(XLIFFVersion12),
+		Files:     []xliff12File{file},
+	}
+}
+
+func newXLIFF20Document(source, target discordgo.Locale, units []xliffUnit) xliff20Document {
+	file := xliff20File{ID: string(source), Units: make([]xliff20Unit, 0, len(units))}
+	for i, unit := range units {
+		xmlUnit := xliff20Unit{
+			ID:       "u" + strconv.Itoa(i+1),
+			Name:     unit.id,
+			Segments: []xliff20Segment{{Source: unit.source, Target: unit.target}},
+		}
+		if !unit.translatable {
+			xmlUnit.Translate = xliffNo
+		}
+		if unit.note != "" {
+			xmlUnit.Notes = &xliff20Notes{Notes: []string{unit.note}}
+		}
+		file.Units = append(file.Units, xmlUnit)
+	}
+
+	return xliff20Document{
+		Namespace:      xliff20Namespace,
+		Version:        string(XLIFFVersion20),
+		SourceLanguage: string(source),
+		TargetLanguage: string(target),
+		Files:          []xliff20File{file},
+	}
+}
+
+// decodeXLIFF converts the units of a XLIFF 1.2 or 2.x file into bundle content: translated
+// units are stored at their path with their target, units that are not translatable with
+// their source and unit notes as notes of their key.
+func decodeXLIFF(buf []byte) (map[string]any, error) {
+	var root struct {
+		Version string `xml:"version,attr"`
+	}
+	if err := xml.Unmarshal(buf, &root); err != nil {
+		return nil, err
+	}
+
+	var units []xliffUnit
+	var err error
+	switch {
+	case root.Version == string(XLIFFVersion12):
+		units, err = decodeXLIFF12(buf)
+	case strings.HasPrefix(root.Version, "2."):
+		units, err = decodeXLIFF20(buf)
+	default:
+		return nil, fmt.Errorf("unsupported XLIFF version '%s'", root.Version)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return xliffContent(units)
+}
+
+func decodeXLIFF12(buf []byte) ([]xliffUnit, error) {
+	var document xliff12Document
+	if err := xml.Unmarshal(buf, &document); err != nil {
+		return nil, err
+	}
+
+	var units []xliffUnit
+	for _, file := range document.Files {
+		for _, xmlUnit := range file.Units {
+			units = append(units, xliffUnit{
+				id:           cmp.Or(xmlUnit.Resname, xmlUnit.ID),
+				source:       xmlUnit.Source,
+				target:       xmlUnit.Target,
+				note:         strings.Join(xmlUnit.Notes, xliffNoteDelim),
+				translatable: xmlUnit.Translate != xliffNo,
+			})
+		}
+	}
+
+	return units, nil
+}
+
+func decodeXLIFF20(buf []byte) ([]xliffUnit, error) {
+	var document xliff20Document
+	if err := xml.Unmarshal(buf, &document); err != nil {
+		return nil, err
+	}
+
+	var units []xliffUnit
+	for _, file := range document.Files {
+		for _, xmlUnit := range file.Units {
+			var source, target strings.Builder
+			for _, segment := range xmlUnit.Segments {
+				source.WriteString(segment.Source)
+				target.WriteString(segment.Target)
+			}
+
+			var notes []string
+			if xmlUnit.Notes != nil {
+				notes = xmlUnit.Notes.Notes
+			}
+
+			units = append(units, xliffUnit{
+				id:           cmp.Or(xmlUnit.Name, xmlUnit.ID),
+				source:       source.String(),
+				target:       target.String(),
+				note:         strings.Join(notes, xliffNoteDelim),
+				translatable: xmlUnit.Translate != xliffNo,
+			})
+		}
+	}
+
+	return units, nil
+}
+
+// xliffContent rebuilds the bundle content from units. Untranslated units are skipped, so
+// selectors and plural types are only restored for the objects holding translated units.
+func xliffContent(units []xliffUnit) (map[string]any, error) {
+	content := make(map[string]any)
+	notes := make(map[string]any)
+	var reserved []xliffUnit
+	for _, unit := range units {
+		path, err := parseXLIFFPath(unit.id)
+		if err != nil {
+			return nil, err
+		}
+
+		last := path[len(path)-1].name
+		if len(path) > 1 && (last == selectKey || last == pluralKey) {
+			reserved = append(reserved, unit)
+			continue
+		}
+
+		value := unit.target
+		if !unit.translatable {
+			value = unit.source
+		}
+		if value == "" {
+			continue
+		}
+
+		if _, err = setXLIFFValue(content, path, value); err != nil {
+			return nil, fmt.Errorf("unit '%s' %w", unit.id, err)
+		}
+		if unit.note != "" {
+			notes[path[0].name] = unit.note
+		}
+	}
+
+	for _, unit := range reserved {
+		path, _ := parseXLIFFPath(unit.id)
+		if _, isObject := getXLIFFValue(content, path[:len(path)-1]).(map[string]any); !isObject {
+			continue
+		}
+
+		if _, err := setXLIFFValue(content, path, unit.source); err != nil {
+			return nil, fmt.Errorf("unit '%s' %w", unit.id, err)
+		}
+	}
+
+	content = toBundleContent(content).(map[string]any)
+	if len(notes) > 0 {
+		content[notesKey] = notes
+	}
+
+	return content, nil
+}
+
+// parseXLIFFPath splits the id of a unit into the key and the plural forms or cases leading
+// to its value.
+func parseXLIFFPath(id string) ([]xliffStep, error) {
+	names := strings.Split(id, xliffPathDelim)
+	path := make([]xliffStep, 0, len(names))
+	for _, name := range names {
+		step := xliffStep{name: name, index: -1}
+		if prefix, found := strings.CutSuffix(name, xliffIndexEnd); found {
+			if start := strings.LastIndex(prefix, xliffIndexStart); start >= 0 {
+				index, err := strconv.Atoi(prefix[start+1:])
+				if err != nil || index < 0 {
+					return nil, fmt.Errorf("unit '%s' has an invalid index", id)
+				}
+				step = xliffStep{name: prefix[:start], index: index}
+			}
+		}
+
+		if step.name == "" {
+			return nil, fmt.Errorf("unit '%s' has an empty path", id)
+		}
+		path = append(path, step)
+	}
+
+	return path, nil
+}
+
+// setXLIFFValue returns node with value stored at path, creating the objects and arrays on
+// the way.
+func setXLIFFValue(node any, path []xliffStep, value string) (any, error) {
+	if len(path) == 0 {
+		if node != nil {
+			return nil, errors.New("is declared twice")
+		}
+		return value, nil
+	}
+
+	object, isObject := node.(map[string]any)
+	if node != nil && !isObject {
+		return nil, errors.New("conflicts with another unit")
+	}
+	if object == nil {
+		object = make(map[string]any)
+	}
+
+	step := path[0]
+	if step.index < 0 {
+		child, err := setXLIFFValue(object[step.name], path[1:], value)
+		if err != nil {
+			return nil, err
+		}
+		object[step.name] = child
+		return object, nil
+	}
+
+	array, isArray := object[step.name].(xliffArray)
+	if object[step.name] != nil && !isArray {
+		return nil, errors.New("conflicts with another unit")
+	}
+	if array == nil {
+		array = make(xliffArray)
+	}
+
+	child, err := setXLIFFValue(array[step.index], path[1:], value)
+	if err != nil {
+		return nil, err
+	}
+	array[step.index] = child
+	object[step.name] = array
+
+	return object, nil
+}
+
+// getXLIFFValue returns the value stored at path in node, nil if none.
+func getXLIFFValue(node any, path []xliffStep) any {
+	for _, step := range path {
+		object, isObject := node.(map[string]any)
+		if !isObject {
+			return nil
+		}
+
+		node = object[step.name]
+		if step.index >= 0 {
+			array, isArray := node.(xliffArray)
+			if !isArray {
+				return nil
+			}
+			node = array[step.index]
+		}
+	}
+
+	return node
+}
+
+// toBundleContent turns the arrays of node into bundle arrays ordered by index.
+func toBundleContent(node any) any {
+	switch value := node.(type) {
+	case map[string]any:
+		for name, child := range value {
+			value[name] = toBundleContent(child)
+		}
+	case xliffArray:
+		values := make([]any, 0, len(value))
+		for _, index := range slices.Sorted(maps.Keys(value)) {
+			values = append(values, toBundleContent(value[index]))
+		}
+		return values
+	}
+
+	return node
+}
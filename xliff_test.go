@@ -0,0 +1,115 @@
+package discordgoi18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test splitting unit ids into keys, plural forms, cases and array indexes
+func TestParseXLIFFPath(t *testing.T) {
+	for id, expected := range map[string][]xliffStep{
+		"hello":                    {{name: "hello", index: -1}},
+		"command.ping.description": {{name: "command.ping.description", index: -1}},
+		"greetings[1]":             {{name: "greetings", index: 1}},
+		"coins#one":                {{name: "coins", index: -1}, {name: "one", index: -1}},
+		"gender#other#few[2]":      {{name: "gender", index: -1}, {name: "other", index: -1}, {name: "few", index: 2}},
+		"weird]":                   {{name: "weird]", index: -1}},
+	} {
+		path, err := parseXLIFFPath(id)
+		assert.NoError(t, err, id)
+		assert.Equal(t, expected, path, id)
+	}
+
+	for _, id := range []string{"", "coins#", "#one", "greetings[x]", "greetings[-1]", "[0]"} {
+		_, err := parseXLIFFPath(id)
+		assert.Error(t, err, id)
+	}
+}
+
+// Test rebuilding bundle content from XLIFF units
+func TestXLIFFContent(t *testing.T) {
+	content, err := xliffContent([]xliffUnit{
+		{id: "hello", source: "Hello", target: "Bonjour", note: "Greeting", translatable: true},
+		{id: "greetings[2]", source: "Yo", target: "Wesh", translatable: true},
+		{id: "greetings[0]", source: "Hi", target: "Salut", translatable: true},
+		{id: "greetings[1]", source: "Hey", translatable: true},
+		{id: "coins#$plural", source: "ordinal"},
+		{id: "coins#one", source: "{{ .count }}st", target: "{{ .count }}er", translatable: true},
+		{id: "coins#other", source: "{{ .count }}th", target: "{{ .count }}e", translatable: true},
+		{id: "gender#$select", source: "gender"},
+		{id: "gender#other", source: "They", translatable: true},
+		{id: "brand", source: "Discord"},
+		{id: "untranslated", source: "Untranslated", note: "Lost", translatable: true},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"hello":     "Bonjour",
+		"greetings": []any{"Salut", "Wesh"},
+		"coins":     map[string]any{pluralKey: pluralTypeOrdinal, "one": "{{ .count }}er", "other": "{{ .count }}e"},
+		"brand":     "Discord",
+		notesKey:    map[string]any{"hello": "Greeting"},
+	}, content)
+
+	for _, units := range [][]xliffUnit{
+		{{id: "hello", target: "Bonjour", translatable: true}, {id: "hello", target: "Salut", translatable: true}},
+		{{id: "hello", target: "Bonjour", translatable: true}, {id: "hello#one", target: "Salut", translatable: true}},
+		{{id: "hello", target: "Bonjour", translatable: true}, {id: "hello[0]", target: "Salut", translatable: true}},
+		{{id: "hello[0]", target: "Bonjour", translatable: true}, {id: "hello#one", target: "Salut", translatable: true}},
+		{{id: "hello[", target: "Bonjour", translatable: true}, {id: "hello#", target: "Salut", translatable: true}},
+	} {
+		_, err = xliffContent(units)
+		assert.Error(t, err)
+	}
+}
+
+// Test decoding XLIFF 1.2 and 2.x files
+func TestDecodeXLIFF(t *testing.T) {
+	expected := map[string]any{
+		"hello":     "Bonjour",
+		"greetings": []any{"Salut", "Coucou"},
+		notesKey:    map[string]any{"hello": "Greeting\nShort"},
+	}
+
+	content, err := decodeXLIFF([]byte(`<?xml version="1.0" encoding="UTF-8"?>
+<xliff version="1.2" xmlns="urn:oasis:names:tc:xliff:document:1.2">
+  <file original="en-US" source-language="en-US" target-language="fr" datatype="plaintext">
+    <body>
+      <trans-unit id="1" resname="hello">
+        <source>Hello</source>
+        <target>Bonjour</target>
+        <note>Greeting</note>
+        <note>Short</note>
+      </trans-unit>
+    </body>
+  </file>
+  <file original="days" source-language="en-US" datatype="plaintext">
+    <body>
+      <trans-unit id="greetings[0]"><source>Hi</source><target>Salut</target></trans-unit>
+      <trans-unit id="greetings[1]"><source>Hey</source><target>Coucou</target></trans-unit>
+    </body>
+  </file>
+</xliff>`))
+	assert.NoError(t, err)
+	assert.Equal(t, expected, content)
+
+	content, err = decodeXLIFF([]byte(`<xliff version="2.1" xmlns="urn:oasis:names:tc:xliff:document:2.0" srcLang="en-US" trgLang="fr">
+  <file id="f1">
+    <unit id="hello">
+      <notes><note>Greeting</note><note>Short</note></notes>
+      <segment><source>Hello</source><target>Bon</target></segment>
+      <segment><source>!</source><target>jour</target></segment>
+    </unit>
+    <unit id="u2" name="greetings[0]"><segment><source>Hi</source><target>Salut</target></segment></unit>
+    <unit id="u3" name="greetings[1]"><segment><source>Hey</source><target>Coucou</target></segment></unit>
+  </file>
+</xliff>`))
+	assert.NoError(t, err)
+	assert.Equal(t, expected, content)
+
+	_, err = decodeXLIFF([]byte(`<xliff version="1.1"></xliff>`))
+	assert.ErrorContains(t, err, "unsupported XLIFF version '1.1'")
+
+	_, err = decodeXLIFF([]byte(`<xliff version="1.2"><file>`))
+	assert.Error(t, err)
+}